@@ -0,0 +1,99 @@
+package xlsx
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+)
+
+// maxRatDecimals is the number of decimals of rationals without a finite decimal form like 1/3
+const maxRatDecimals = 30
+
+var (
+	bigIntType   = reflect.TypeOf(big.Int{})
+	bigFloatType = reflect.TypeOf(big.Float{})
+	bigRatType   = reflect.TypeOf(big.Rat{})
+)
+
+// bigNumber returns big.Int, big.Float and big.Rat values as numbers with all their digits
+func bigNumber(value reflect.Value) (json.Number, bool) {
+	if !value.CanAddr() {
+		ptr := reflect.New(value.Type())
+		ptr.Elem().Set(value)
+		value = ptr.Elem()
+	}
+	switch v := value.Addr().Interface().(type) {
+	case *big.Int:
+		return json.Number(v.String()), true
+	case *big.Float:
+		return json.Number(v.Text('f', -1)), true
+	case *big.Rat:
+		return json.Number(v.FloatString(ratDecimals(v))), true
+	}
+	return "", false
+}
+
+// ratDecimals returns the number of decimals of the rational, maxRatDecimals if they don't end
+func ratDecimals(r *big.Rat) int {
+	denom := new(big.Int).Set(r.Denom())
+	twos, fives := 0, 0
+	for ; denom.Bit(0) == 0; twos++ {
+		denom.Rsh(denom, 1)
+	}
+	five, mod := big.NewInt(5), new(big.Int)
+	for {
+		quo, rem := new(big.Int).QuoRem(denom, five, mod)
+		if rem.Sign() != 0 {
+			break
+		}
+		denom = quo
+		fives++
+	}
+	if denom.Cmp(big.NewInt(1)) != 0 {
+		return maxRatDecimals
+	}
+	if twos > fives {
+		return twos
+	}
+	return fives
+}
+
+// decimalNumber returns the text of the value as a number if it is one, see the decimal tag
+func decimalNumber(text string) (json.Number, bool) {
+	if _, ok := new(big.Rat).SetString(strings.TrimSpace(text)); !ok {
+		return "", false
+	}
+	return json.Number(strings.TrimSpace(text)), true
+}
+
+// convertBig sets big.Int, big.Float and big.Rat values without going through float64,
+// it returns false if the value isn't one of them
+func convertBig(value reflect.Value, raw string) (bool, error) {
+	switch value.Type() {
+	case bigIntType:
+		s, ok := toIntegerDecimalString(raw)
+		if !ok {
+			return true, fmt.Errorf("can't convert %q to an integer", raw)
+		}
+		value.Addr().Interface().(*big.Int).SetString(s, 10)
+	case bigFloatType:
+		// Four bits per digit keep every digit of the cell
+		s := strings.TrimSpace(raw)
+		f, _, err := big.ParseFloat(s, 10, uint(len(s))*4+64, big.ToNearestEven)
+		if err != nil {
+			return true, fmt.Errorf("can't convert %q to a number", raw)
+		}
+		value.Set(reflect.ValueOf(*f))
+	case bigRatType:
+		r, ok := new(big.Rat).SetString(strings.TrimSpace(raw))
+		if !ok {
+			return true, fmt.Errorf("can't convert %q to a number", raw)
+		}
+		value.Set(reflect.ValueOf(*r))
+	default:
+		return false, nil
+	}
+	return true, nil
+}
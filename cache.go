@@ -0,0 +1,94 @@
+package xlsx
+
+import (
+	"sync"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// SheetCache keeps rows of sheets read by Unmarshal, so a sheet read into several struct types is scanned once
+//
+//	cache := xlsx.NewSheetCache()
+//	err := xlsx.UnmarshalSheet(file, "Orders", &orders, xlsx.WithSheetCache(cache))
+//	...
+//	err = xlsx.UnmarshalSheet(file, "Orders", &totals, xlsx.WithSheetCache(cache))
+//
+// Cached rows don't see later changes of the file, Forget drops them
+// The cache can be shared by goroutines
+type SheetCache struct {
+	mu     sync.Mutex
+	sheets map[cachedSheetKey]*cachedSheet
+}
+
+// NewSheetCache returns the empty cache
+func NewSheetCache() *SheetCache {
+	return &SheetCache{sheets: map[cachedSheetKey]*cachedSheet{}}
+}
+
+// WithSheetCache reads rows of sheets from the cache, they are scanned and added on the first read
+func WithSheetCache(cache *SheetCache) Option {
+	return func(o *options) {
+		o.read.Cache = cache
+	}
+}
+
+// Forget drops cached rows of the sheet, e.g. after it is changed
+func (c *SheetCache) Forget(file *excelize.File, sheetName string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.sheets, cachedSheetKey{file: file, sheetName: sheetName})
+}
+
+type cachedSheetKey struct {
+	file      *excelize.File
+	sheetName string
+}
+
+// cachedSheet are raw and formatted values of sheet rows
+type cachedSheet struct {
+	raw       [][]string
+	formatted [][]string
+}
+
+// rowReader returns the reader of cached rows of the sheet, the sheet is scanned if it isn't cached
+func (c *SheetCache) rowReader(file *excelize.File, sheetName string) (*rowReader, error) {
+	key := cachedSheetKey{file: file, sheetName: sheetName}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	sheet, ok := c.sheets[key]
+	if !ok {
+		rows, err := newRowReader(file, sheetName)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		sheet = &cachedSheet{}
+		for rows.Next() {
+			raw, formatted, err := rows.row()
+			if err != nil {
+				return nil, err
+			}
+			sheet.raw = append(sheet.raw, raw)
+			sheet.formatted = append(sheet.formatted, formatted)
+		}
+		c.sheets[key] = sheet
+	}
+	return &rowReader{cached: sheet, sheetName: sheetName}, nil
+}
+
+// cachedRow returns copies of the current cached row, returned rows are changed by cleaners
+func (r *rowReader) cachedRow() ([]string, []string) {
+	raw := append([]string(nil), r.cached.raw[r.rowIdx-1]...)
+	formatted := append([]string(nil), r.cached.formatted[r.rowIdx-1]...)
+	return raw, formatted
+}
+
+// newRowReader returns the reader of the sheet rows, cached ones with WithSheetCache
+func (o UnmarshalOptions) newRowReader(file *excelize.File, sheetName string) (*rowReader, error) {
+	if o.Cache != nil {
+		return o.Cache.rowReader(file, sheetName)
+	}
+	return newRowReader(file, sheetName)
+}
@@ -0,0 +1,34 @@
+package xlsx
+
+// WithCalcFormulas makes Unmarshal calculate formula cells instead of reading results saved in the file,
+// files written by libraries often have no results, Excel saves results calculated on its last save
+// Cells with formulas excelize can't calculate keep saved results
+func WithCalcFormulas() Option {
+	return func(o *options) {
+		o.read.CalcFormulas = true
+	}
+}
+
+// calcFormulas replaces values of formula cells of the current row with calculated results
+// Results aren't formatted, so both values are the raw result
+func (r *rowReader) calcFormulas(raw []string, formatted []string) error {
+	for columnIdx := range raw {
+		cell := GetCellName(columnIdx, r.rowIdx)
+		formula, err := r.calc.GetCellFormula(r.sheetName, cell)
+		if err != nil {
+			return err
+		}
+		if formula == "" {
+			continue
+		}
+		result, err := r.calc.CalcCellValue(r.sheetName, cell)
+		if err != nil {
+			continue
+		}
+		raw[columnIdx] = result
+		if columnIdx < len(formatted) {
+			formatted[columnIdx] = result
+		}
+	}
+	return nil
+}
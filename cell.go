@@ -0,0 +1,192 @@
+package xlsx
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// CellRange is a rectangular range of cells
+// Column indexes are zero based and row indexes are one based, the same as in GetCellName
+type CellRange struct {
+	FromColumn int
+	FromRow    int
+	ToColumn   int
+	ToRow      int
+}
+
+// ParseCellName converts a cell name like "AB12" to a zero based column index and a row index
+func ParseCellName(cell string) (columnIdx int, rowIdx int, err error) {
+	column, row, err := excelize.CellNameToCoordinates(cell)
+	if err != nil {
+		return 0, 0, err
+	}
+	return column - 1, row, nil
+}
+
+// CellNameToIndices converts a cell name like "AB12" to a zero based column index and a row index, see ParseCellName
+func CellNameToIndices(cell string) (columnIdx int, rowIdx int, err error) {
+	return ParseCellName(cell)
+}
+
+// IndicesToCellName converts a zero based column index and a row index to a cell name like "AB12"
+// Unlike GetCellName it returns an error for coordinates outside of the sheet
+func IndicesToCellName(columnIdx int, rowIdx int) (string, error) {
+	return excelize.CoordinatesToCellName(columnIdx+1, rowIdx)
+}
+
+// ParseCellRange converts a range like "A1:C10" to CellRange
+// A single cell name is treated as a one cell range
+func ParseCellRange(ref string) (CellRange, error) {
+	parts := strings.Split(strings.ReplaceAll(ref, "$", ""), ":")
+	if len(parts) > 2 {
+		return CellRange{}, fmt.Errorf("invalid cell range %q", ref)
+	}
+
+	var r CellRange
+	var err error
+	r.FromColumn, r.FromRow, err = ParseCellName(parts[0])
+	if err != nil {
+		return CellRange{}, err
+	}
+	r.ToColumn, r.ToRow = r.FromColumn, r.FromRow
+	if len(parts) == 2 {
+		r.ToColumn, r.ToRow, err = ParseCellName(parts[1])
+		if err != nil {
+			return CellRange{}, err
+		}
+	}
+	return r.normalize(), nil
+}
+
+// String returns the range in A1 notation, e.g. "A1:C10"
+func (r CellRange) String() string {
+	return GetCellName(r.FromColumn, r.FromRow) + ":" + GetCellName(r.ToColumn, r.ToRow)
+}
+
+// Columns returns the number of columns in the range
+func (r CellRange) Columns() int {
+	return r.ToColumn - r.FromColumn + 1
+}
+
+// Rows returns the number of rows in the range
+func (r CellRange) Rows() int {
+	return r.ToRow - r.FromRow + 1
+}
+
+// Contains reports whether the cell is inside the range
+func (r CellRange) Contains(columnIdx int, rowIdx int) bool {
+	return columnIdx >= r.FromColumn && columnIdx <= r.ToColumn &&
+		rowIdx >= r.FromRow && rowIdx <= r.ToRow
+}
+
+// Each calls fn for every cell of the range, row by row
+// Iteration stops at the first error returned by fn
+func (r CellRange) Each(fn func(columnIdx int, rowIdx int) error) error {
+	for rowIdx := r.FromRow; rowIdx <= r.ToRow; rowIdx++ {
+		for columnIdx := r.FromColumn; columnIdx <= r.ToColumn; columnIdx++ {
+			if err := fn(columnIdx, rowIdx); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Cells returns names of all cells of the range, row by row
+func (r CellRange) Cells() []string {
+	cells := make([]string, 0, r.Columns()*r.Rows())
+	r.Each(func(columnIdx int, rowIdx int) error {
+		cells = append(cells, GetCellName(columnIdx, rowIdx))
+		return nil
+	})
+	return cells
+}
+
+func (r CellRange) normalize() CellRange {
+	if r.FromColumn > r.ToColumn {
+		r.FromColumn, r.ToColumn = r.ToColumn, r.FromColumn
+	}
+	if r.FromRow > r.ToRow {
+		r.FromRow, r.ToRow = r.ToRow, r.FromRow
+	}
+	return r
+}
+
+// ShiftRange moves the range by the given number of columns and rows
+func ShiftRange(r CellRange, columns int, rows int) (CellRange, error) {
+	shifted := CellRange{
+		FromColumn: r.FromColumn + columns,
+		FromRow:    r.FromRow + rows,
+		ToColumn:   r.ToColumn + columns,
+		ToRow:      r.ToRow + rows,
+	}
+	if shifted.FromColumn < 0 || shifted.ToColumn >= excelize.MaxColumns ||
+		shifted.FromRow < 1 || shifted.ToRow > excelize.TotalRows {
+		return CellRange{}, fmt.Errorf("range %s shifted by %d columns and %d rows is out of the sheet", r, columns, rows)
+	}
+	return shifted, nil
+}
+
+// RangeOf returns the range Write uses for data, header row included
+// Options are the ones passed to Write, banners, type rows, totals and group summaries move and add rows
+func RangeOf(data interface{}, opts ...Option) (CellRange, error) {
+	if data == nil || reflect.TypeOf(data).Kind() != reflect.Slice {
+		return CellRange{}, fmt.Errorf("slice only is allowed")
+	}
+
+	slice := reflect.ValueOf(data)
+	elemType := slice.Type().Elem()
+	if elemType.Kind() != reflect.Struct {
+		return CellRange{}, fmt.Errorf("slice of structs only is allowed")
+	}
+
+	columns := getColumns(elemType)
+	if len(columns) == 0 {
+		return CellRange{}, fmt.Errorf("no columns to write")
+	}
+
+	o := newOptions(opts).write
+	headerRow, _, _, dataRow := o.sheetRows(columns)
+	rows := slice.Len()
+	if o.Grouping.GroupBy != "" {
+		rows = groupedRows(slice, columns, o.Grouping)
+	}
+	return CellRange{
+		FromColumn: 0,
+		FromRow:    headerRow,
+		ToColumn:   lastColumnIndex(columns),
+		ToRow:      dataRow + rows - 1,
+	}, nil
+}
+
+// ToR1C1 converts a cell name like "C5" to R1C1 notation ("R5C3")
+func ToR1C1(cell string) (string, error) {
+	columnIdx, rowIdx, err := ParseCellName(cell)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("R%dC%d", rowIdx, columnIdx+1), nil
+}
+
+// FromR1C1 converts an R1C1 reference like "R5C3" to a cell name ("C5")
+func FromR1C1(ref string) (string, error) {
+	upper := strings.ToUpper(ref)
+	c := strings.Index(upper, "C")
+	if !strings.HasPrefix(upper, "R") || c < 2 {
+		return "", fmt.Errorf("invalid R1C1 reference %q", ref)
+	}
+
+	row, err := strconv.Atoi(upper[1:c])
+	if err != nil {
+		return "", fmt.Errorf("invalid R1C1 reference %q", ref)
+	}
+	column, err := strconv.Atoi(upper[c+1:])
+	if err != nil {
+		return "", fmt.Errorf("invalid R1C1 reference %q", ref)
+	}
+	return excelize.CoordinatesToCellName(column, row)
+}
@@ -0,0 +1,45 @@
+package xlsx
+
+import (
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestRangeOfOptions(t *testing.T) {
+	type row struct {
+		Region string
+		Qty    int
+	}
+	data := []row{{"a", 1}, {"a", 2}, {"b", 3}}
+	opts := []Option{WithBanner("Report"), WithTypeRow(), WithTotals(map[string]string{"Qty": "sum"}),
+		WithGrouping(Grouping{GroupBy: "Region"})}
+
+	var layout Layout
+	file := excelize.NewFile()
+	if err := Write(file, "Data", data, append(opts, WithLayout(func(l Layout) { layout = l }))...); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := RangeOf(data, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Banner, header, type row, totals, three rows and two summaries
+	want := CellRange{FromColumn: 0, FromRow: 2, ToColumn: 1, ToRow: 9}
+	if got != want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+	if got.FromRow != layout.Header.FromRow || got.ToRow != layout.Data.ToRow {
+		t.Fatalf("got %+v, Write wrote header %+v and data %+v", got, layout.Header, layout.Data)
+	}
+}
+
+func TestRangeOfNil(t *testing.T) {
+	if _, err := RangeOf(nil); err == nil {
+		t.Fatal("got no error for nil data")
+	}
+	if _, err := RangeOf(1); err == nil {
+		t.Fatal("got no error for a number")
+	}
+}
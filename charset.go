@@ -0,0 +1,63 @@
+package xlsx
+
+import (
+	"bytes"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// WithCharset sets the encoding of TSV text like charmap.Windows1251, it's detected by default
+func WithCharset(charset encoding.Encoding) Option {
+	return func(o *options) {
+		o.read.Charset = charset
+	}
+}
+
+// decodeText converts the text to UTF-8
+// Without the charset UTF-16 is detected by the byte order mark or zero bytes of Latin letters,
+// UTF-8 by valid sequences and other text is Windows-1251 of old Cyrillic systems
+func decodeText(data []byte, charset encoding.Encoding) (string, error) {
+	if charset == nil {
+		charset = detectCharset(data)
+	}
+	if charset == nil {
+		return string(bytes.TrimPrefix(data, utf8BOM)), nil
+	}
+	decoded, err := charset.NewDecoder().Bytes(data)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes.TrimPrefix(decoded, utf8BOM)), nil
+}
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// detectCharset returns the encoding of the text, nil for UTF-8
+func detectCharset(data []byte) encoding.Encoding {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}), isUTF16(data, 1):
+		return unicode.UTF16(unicode.LittleEndian, unicode.UseBOM)
+	case bytes.HasPrefix(data, []byte{0xFE, 0xFF}), isUTF16(data, 0):
+		return unicode.UTF16(unicode.BigEndian, unicode.UseBOM)
+	case utf8.Valid(data):
+		return nil
+	}
+	return charmap.Windows1251
+}
+
+// isUTF16 reports whether most bytes at odd or even positions are zeros like in UTF-16 of ASCII text
+func isUTF16(data []byte, zeroAt int) bool {
+	if len(data) < 2 || len(data)%2 != 0 {
+		return false
+	}
+	zeros := 0
+	for i := zeroAt; i < len(data); i += 2 {
+		if data[i] == 0 {
+			zeros++
+		}
+	}
+	return zeros*2 > len(data)/2
+}
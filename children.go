@@ -0,0 +1,106 @@
+package xlsx
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// writeChildren writes children of all elements to their own sheet, see Write
+// Every child row starts with key columns of its parent, a child field read from
+// a column with the same name gets the parent key instead
+func writeChildren(file *excelize.File, slice reflect.Value, o *options) error {
+	elemType := slice.Type().Elem()
+	children, childType, _ := childrenField(elemType)
+
+	keys := keyColumns(getColumns(elemType))
+	if len(keys) == 0 {
+		return fmt.Errorf("%s has children, but no fields with the key tag", elemType)
+	}
+
+	sheetName := childSheetName(children)
+
+	// Child fields which hold parent keys, or nothing if every key gets its own column
+	childKeys := make([]column, 0, len(keys))
+	for _, k := range keys {
+		if c, ok := findKeyColumn(childType, k.name); ok {
+			childKeys = append(childKeys, c)
+		}
+	}
+	ownKeys := len(childKeys) == len(keys)
+
+	rowType, columns := childType, getColumns(childType)
+	if !ownKeys {
+		rowType, columns = childRowType(keys, childType)
+	}
+	if err := checkColumns(columns); err != nil {
+		return fmt.Errorf("sheet %s: %w", sheetName, err)
+	}
+
+	rows := reflect.MakeSlice(reflect.SliceOf(rowType), 0, 0)
+	for i := 0; i < slice.Len(); i++ {
+		parent := slice.Index(i)
+		values := parent.FieldByIndex(children.Index)
+		for j := 0; j < values.Len(); j++ {
+			child := reflect.Indirect(values.Index(j))
+			if !child.IsValid() {
+				continue
+			}
+
+			row := reflect.New(rowType).Elem()
+			if ownKeys {
+				row.Set(child)
+				for k, c := range childKeys {
+					row.FieldByIndex(c.field.Index).Set(parent.FieldByIndex(keys[k].field.Index))
+				}
+			} else {
+				for k, c := range keys {
+					row.Field(k).Set(parent.FieldByIndex(c.field.Index))
+				}
+				row.Field(len(keys)).Set(child)
+			}
+			rows = reflect.Append(rows, row)
+		}
+	}
+
+	childOptions := *o
+	childOptions.write.SheetRouter = nil
+	childOptions.write.Grouping = Grouping{}
+	childOptions.write.Provenance = nil
+	childOptions.progress = nil
+	return writeSheet(file, sheetName, rows, columns, &childOptions)
+}
+
+// childSheetName returns the name of the sheet of children, the sheet tag or the field name
+func childSheetName(children reflect.StructField) string {
+	if sheetName := getTag(children, "sheet"); sheetName != "" {
+		return sheetName
+	}
+	return children.Name
+}
+
+// childRowType returns the struct type of child rows with parent key fields followed by the child
+// and its columns, fields of the child keep their own header names
+func childRowType(keys []column, childType reflect.Type) (reflect.Type, []column) {
+	var fields []reflect.StructField
+	var columns []column
+	for i, k := range keys {
+		fields = append(fields, reflect.StructField{
+			Name: fmt.Sprintf("Key%d", i),
+			Type: k.field.Type,
+			Tag:  k.field.Tag,
+		})
+		field := k.field
+		field.Index = []int{i}
+		columns = append(columns, column{field: field, index: i, name: k.name, aliases: k.aliases})
+	}
+	fields = append(fields, reflect.StructField{Name: "Child", Type: childType})
+
+	for _, c := range getColumns(childType) {
+		c.field.Index = append([]int{len(keys)}, c.field.Index...)
+		c.index += len(keys)
+		columns = append(columns, c)
+	}
+	return reflect.StructOf(fields), columns
+}
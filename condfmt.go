@@ -0,0 +1,89 @@
+package xlsx
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// WithConditionalFormats sets conditional formats of data cells of fields with the condfmt tag by name,
+// e.g. `xlsx:"condfmt:overdue"`, they replace built-in formats with the same name, see conditionalFormat
+// Format fields are style IDs of excelize.File.NewConditionalStyle
+func WithConditionalFormats(formats map[string][]excelize.ConditionalFormatOptions) Option {
+	return func(o *options) {
+		o.write.ConditionalFormats = formats
+	}
+}
+
+// setConditionalFormats adds conditional formats of the condfmt tag to data cells of columns,
+// names of several formats of the column are separated by "|" like `xlsx:"condfmt:red_if_negative|databar"`
+func setConditionalFormats(file *excelize.File, sheetName string, columns []column, fromRow int, toRow int, o WriteOptions) error {
+	for _, c := range columns {
+		tag := getTag(c.field, "condfmt")
+		if tag == "" {
+			continue
+		}
+		var formats []excelize.ConditionalFormatOptions
+		for _, name := range strings.Split(tag, "|") {
+			format, err := conditionalFormat(file, name, o)
+			if err != nil {
+				return fmt.Errorf("field %s: %w", c.field.Name, err)
+			}
+			formats = append(formats, format...)
+		}
+		rangeRef := GetCellName(c.index, fromRow) + ":" + GetCellName(c.index, toRow)
+		// excelize takes the style of the last format for all formats of one call, so they are set one by one
+		for _, format := range formats {
+			err := file.SetConditionalFormat(sheetName, rangeRef, []excelize.ConditionalFormatOptions{format})
+			if err != nil {
+				return fmt.Errorf("field %s: %w", c.field.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// conditionalFormat returns the conditional format with the name from options or the built-in one:
+// red_if_negative - red font of negative numbers
+// green_if_positive - green font of positive numbers
+// databar - bars of values
+// colorscale - red, yellow and green fill from the lowest to the highest value
+// colorscale2 - white to green fill from the lowest to the highest value
+// duplicates - red fill of repeated values
+func conditionalFormat(file *excelize.File, name string, o WriteOptions) ([]excelize.ConditionalFormatOptions, error) {
+	if format, ok := o.ConditionalFormats[name]; ok {
+		return format, nil
+	}
+	switch name {
+	case "red_if_negative", "green_if_positive":
+		criteria, color := "<", "#C00000"
+		if name == "green_if_positive" {
+			criteria, color = ">", "#00B050"
+		}
+		style, err := file.NewConditionalStyle(&excelize.Style{Font: &excelize.Font{Color: color}})
+		if err != nil {
+			return nil, err
+		}
+		return []excelize.ConditionalFormatOptions{{Type: "cell", Criteria: criteria, Value: "0", Format: style}}, nil
+	case "databar":
+		return []excelize.ConditionalFormatOptions{{Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarColor: "#638EC6"}}, nil
+	case "colorscale":
+		return []excelize.ConditionalFormatOptions{{Type: "3_color_scale", Criteria: "=",
+			MinType: "min", MidType: "percentile", MaxType: "max", MidValue: "50",
+			MinColor: "#F8696B", MidColor: "#FFEB84", MaxColor: "#63BE7B"}}, nil
+	case "colorscale2":
+		return []excelize.ConditionalFormatOptions{{Type: "2_color_scale", Criteria: "=",
+			MinType: "min", MaxType: "max", MinColor: "#FFFFFF", MaxColor: "#63BE7B"}}, nil
+	case "duplicates":
+		style, err := file.NewConditionalStyle(&excelize.Style{
+			Font: &excelize.Font{Color: "#9C0006"},
+			Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"#FFC7CE"}},
+		})
+		if err != nil {
+			return nil, err
+		}
+		return []excelize.ConditionalFormatOptions{{Type: "duplicate", Criteria: "=", Format: style}}, nil
+	}
+	return nil, fmt.Errorf("unknown conditional format %q", name)
+}
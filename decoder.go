@@ -0,0 +1,196 @@
+package xlsx
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Decoder reads sheet rows one by one, e.g. to process huge sheets without the whole slice in memory
+//
+//	dec, err := xlsx.NewDecoder(file, "Dogs")
+//	...
+//	defer dec.Close()
+//	for dec.Next() {
+//		var dog Dog
+//		if err := dec.Scan(&dog); err != nil {
+//			...
+//		}
+//	}
+//	if err := dec.Err(); err != nil {
+//		...
+//	}
+//
+// Rows are read like by Unmarshal except the children tag, VerifySchema, VerifyLocked and WithUniqueBy
+type Decoder struct {
+	file      *excelize.File
+	sheetName string
+	o         UnmarshalOptions
+	rows      *rowReader
+	cells     *cellDecoder
+
+	header       *header
+	headerParsed bool
+	raw          []string
+	formatted    []string
+	err          error
+	// ended is set at the footer written by Write, rows below it aren't read
+	ended bool
+
+	// mapped are columns of scanned types
+	mapped map[reflect.Type][]column
+}
+
+// NewDecoder returns the decoder of the sheet rows, it must be closed after use
+func NewDecoder(file *excelize.File, sheetName string, opts ...Option) (*Decoder, error) {
+	o := newOptions(opts).read.withDefaults()
+	if o.VerifySchema || o.VerifyLocked {
+		return nil, fmt.Errorf("locked cells and schemas aren't verified by Decoder")
+	}
+	if len(o.UniqueBy) > 0 {
+		return nil, fmt.Errorf("unique fields aren't checked by Decoder")
+	}
+	if index, err := file.GetSheetIndex(sheetName); err != nil || index < 0 {
+		return nil, fmt.Errorf("sheet %q not found", sheetName)
+	}
+
+	rows, err := o.newRowReader(file, sheetName)
+	if err != nil {
+		return nil, err
+	}
+	if err := o.prepareRows(rows, file); err != nil {
+		rows.Close()
+		return nil, err
+	}
+	return &Decoder{
+		file:         file,
+		sheetName:    sheetName,
+		o:            o,
+		rows:         rows,
+		cells:        &cellDecoder{rows: rows, file: file, sheetName: sheetName, o: o, date1904: isDate1904(file)},
+		headerParsed: o.NoHeader,
+		mapped:       map[reflect.Type][]column{},
+	}, nil
+}
+
+// Next moves to the next data row skipping empty ones, false at the end of the sheet or on error
+func (d *Decoder) Next() bool {
+	if d.err != nil || d.ended {
+		return false
+	}
+
+	emptyRows := 0
+	for emptyRows < d.o.EmptyRowGap && d.rows.Next() {
+		rowIdx := d.rows.rowIdx
+		if rowIdx < d.o.HeaderRow || (rowIdx > d.o.HeaderRow && rowIdx < d.o.DataStartRow) {
+			continue
+		}
+
+		raw, formatted, err := d.rows.Row()
+		if err != nil {
+			d.err = err
+			return false
+		}
+
+		if rowIdx == d.o.HeaderRow {
+			d.header = parseHeader(formatted, d.o)
+			d.headerParsed = true
+			continue
+		}
+		if !d.headerParsed {
+			return false
+		}
+		if rowIdx == d.o.HeaderRow+1 && !d.o.NoHeader {
+			if hints, ok := parseTypeRow(d.file, d.sheetName, rowIdx, raw); ok {
+				d.cells.hints = hints
+				continue
+			}
+		}
+		kind := d.header.rowKind(raw)
+		if kind == footerRowKind {
+			d.ended = true
+			return false
+		}
+		if d.header.isExample(raw) || kind != "" {
+			continue
+		}
+
+		if isEmptyRow(raw) {
+			emptyRows++
+			continue
+		}
+		d.raw, d.formatted = raw, formatted
+		return true
+	}
+	return false
+}
+
+// Scan converts the current row to v, a pointer to a struct
+// Cells which can't be converted are returned like by Unmarshal with CollectErrors or Strict options
+func (d *Decoder) Scan(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("pointer to struct is required")
+	}
+	_, err := d.scan(rv.Elem())
+	return err
+}
+
+// scan converts the current row to the struct element and reports whether its mapped cells are empty
+func (d *Decoder) scan(element reflect.Value) (bool, error) {
+	if d.raw == nil {
+		return false, fmt.Errorf("no current row, Next must be called first")
+	}
+
+	elemType := element.Type()
+	mapped, ok := d.mapped[elemType]
+	if !ok {
+		if err := checkRequired(d.sheetName, elemType, d.header, d.o.TemplateVersion); err != nil {
+			return false, err
+		}
+		mapped = mapColumns(elemType, d.header, d.o.TemplateVersion)
+		mapped = append(mapped, mapRestColumns(elemType, d.header, mapped, nil)...)
+		d.mapped[elemType] = mapped
+	}
+
+	d.cells.errors = nil
+	empty, err := d.cells.decode(element, mapped, d.raw, d.formatted)
+	if err != nil {
+		return empty, err
+	}
+	if !empty {
+		if err := d.cells.validate(element, mapped, d.raw, d.formatted); err != nil {
+			return empty, err
+		}
+	}
+	if len(d.cells.errors) > 0 {
+		return empty, d.cells.errors
+	}
+	return empty, nil
+}
+
+// Row returns the index of the current row
+func (d *Decoder) Row() int {
+	return d.rows.rowIdx
+}
+
+// Err returns the error which stopped Next
+func (d *Decoder) Err() error {
+	return d.err
+}
+
+// Close releases the sheet row iterators
+func (d *Decoder) Close() error {
+	return d.rows.Close()
+}
+
+// isEmptyRow reports whether all cells of the row are empty
+func isEmptyRow(raw []string) bool {
+	for _, value := range raw {
+		if value != "" {
+			return false
+		}
+	}
+	return true
+}
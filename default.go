@@ -0,0 +1,34 @@
+package xlsx
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// setDefault sets the value of the default tag to the field of the empty cell, e.g. default:0 or default:UA
+// default:now is the current time of time.Time fields, other defaults are converted like text cells
+func setDefault(value reflect.Value, c column) error {
+	def := getTag(c.field, "default")
+	if def == "" {
+		return nil
+	}
+	if def == "now" && (value.Type() == timeType || value.Type() == reflect.PtrTo(timeType)) {
+		now := time.Now()
+		if value.Kind() == reflect.Ptr {
+			value.Set(reflect.ValueOf(&now))
+		} else {
+			value.Set(reflect.ValueOf(now))
+		}
+		return nil
+	}
+	if err := convertCell(value, def, def, excelize.CellTypeSharedString, false); err != nil {
+		return fmt.Errorf("field %s: default %q: %w", c.field.Name, def, err)
+	}
+	return nil
+}
+
+// timeType is the type of time.Time fields
+var timeType = reflect.TypeOf(time.Time{})
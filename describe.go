@@ -0,0 +1,95 @@
+package xlsx
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Mapping is how Unmarshal would read the sheet into the struct, see DescribeMapping
+type Mapping struct {
+	// Matched are fields found in the sheet in column order
+	Matched []MappedField
+	// UnmatchedFields are names of fields without columns, nested fields are named like "Audit.CreatedAt"
+	UnmatchedFields []string
+	// UnusedColumns are sheet columns no field is read from
+	UnusedColumns []SheetColumn
+}
+
+// MappedField is the field read from the sheet column
+type MappedField struct {
+	Field string
+	SheetColumn
+}
+
+// SheetColumn is the column of the sheet
+type SheetColumn struct {
+	// Column is the column letter like "C"
+	Column string
+	Header string
+}
+
+// DescribeMapping returns fields of the struct v like Contact{} matched with columns of the sheet, unmatched fields
+// and unused columns, e.g. to be confirmed by users before the import
+// Headers are matched like Unmarshal does with the same options, columns of the rest field are matched with it,
+// hidden columns written by Write for itself aren't listed
+func DescribeMapping(file *excelize.File, sheetName string, v interface{}, opts ...Option) (*Mapping, error) {
+	o := newOptions(opts).read.withDefaults()
+	t, err := structType(v)
+	if err != nil {
+		return nil, err
+	}
+	if index, err := file.GetSheetIndex(sheetName); err != nil || index < 0 {
+		return nil, fmt.Errorf("sheet %q not found", sheetName)
+	}
+
+	var h *header
+	if !o.NoHeader {
+		if h, err = readHeader(file, sheetName, o); err != nil {
+			return nil, err
+		}
+	}
+	mapped := mapColumns(t, h, o.TemplateVersion)
+	mapped = append(mapped, mapRestColumns(t, h, mapped)...)
+	sort.SliceStable(mapped, func(i, j int) bool { return mapped[i].index < mapped[j].index })
+
+	m := &Mapping{}
+	used := map[int]bool{}
+	found := map[string]bool{}
+	for _, c := range mapped {
+		used[c.index] = true
+		found[c.field.Name] = true
+		m.Matched = append(m.Matched, MappedField{
+			Field:       c.field.Name,
+			SheetColumn: SheetColumn{Column: getColumnLetter(c.index), Header: h.nameAt(c.index)},
+		})
+	}
+	for _, c := range readColumns(t, o.TemplateVersion) {
+		if !found[c.field.Name] {
+			m.UnmatchedFields = append(m.UnmatchedFields, c.field.Name)
+		}
+	}
+	if h != nil {
+		for _, hn := range h.names {
+			if !used[hn.columnIdx] && !strings.HasPrefix(hn.name, "#") {
+				m.UnusedColumns = append(m.UnusedColumns, SheetColumn{Column: getColumnLetter(hn.columnIdx), Header: hn.name})
+			}
+		}
+	}
+	return m, nil
+}
+
+// nameAt returns the header name of the column, empty in the nil header
+func (h *header) nameAt(columnIdx int) string {
+	if h == nil {
+		return ""
+	}
+	for _, hn := range h.names {
+		if hn.columnIdx == columnIdx {
+			return hn.name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,78 @@
+package xlsx
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// durationFormat is the number format of time.Duration fields without the datefmt tag,
+// hours go beyond 24 like 36:00:00
+const durationFormat = "[h]:mm:ss"
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// isDurationField reports whether the field is time.Duration or a pointer to it
+func isDurationField(field reflect.StructField) bool {
+	t := field.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t == durationType
+}
+
+// durationSerial returns the duration as the Excel serial in days
+func durationSerial(d time.Duration) float64 {
+	return d.Seconds() / (24 * 60 * 60)
+}
+
+// parseDuration parses serials of number cells, clock texts like "1:30:00" or "-0:45" and Go durations like "90m"
+func parseDuration(raw string, formatted string, ctype excelize.CellType) (time.Duration, error) {
+	if ctype != excelize.CellTypeSharedString && ctype != excelize.CellTypeInlineString {
+		if f, err := strconv.ParseFloat(raw, 64); err == nil {
+			return time.Duration(math.Round(f * 24 * float64(time.Hour))), nil
+		}
+	}
+
+	s := strings.TrimSpace(formatted)
+	if d, ok := parseClock(s); ok {
+		return d, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return d, nil
+	}
+	return 0, fmt.Errorf("can't parse duration %q", formatted)
+}
+
+// parseClock parses hours, minutes and optional seconds with a fraction like "36:05:30.5"
+func parseClock(s string) (time.Duration, bool) {
+	sign := time.Duration(1)
+	if strings.HasPrefix(s, "-") {
+		sign, s = -1, s[1:]
+	}
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, false
+	}
+	hours, err := strconv.ParseUint(parts[0], 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	minutes, err := strconv.ParseUint(parts[1], 10, 8)
+	if err != nil || minutes > 59 {
+		return 0, false
+	}
+	var seconds float64
+	if len(parts) == 3 {
+		if seconds, err = strconv.ParseFloat(parts[2], 64); err != nil || seconds < 0 || seconds >= 60 || strings.HasPrefix(parts[2], "+") {
+			return 0, false
+		}
+	}
+	d := time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(math.Round(seconds*float64(time.Second)))
+	return sign * d, true
+}
@@ -0,0 +1,90 @@
+package xlsx
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CellError is a cell which can't be converted to the struct field
+type CellError struct {
+	SheetName string
+	Cell      string
+	Header    string
+	Field     string
+	Raw       string
+	Err       error
+
+	// invalid means the cell is converted, but breaks validation rules
+	invalid bool
+}
+
+func (e *CellError) Error() string {
+	problem := "can't convert"
+	if e.invalid {
+		problem = "invalid"
+	}
+	return fmt.Sprintf("sheet %s cell %s (column %q, field %s): %s %q: %v",
+		e.SheetName, e.Cell, e.Header, e.Field, problem, e.Raw, e.Err)
+}
+
+func (e *CellError) Unwrap() error {
+	return e.Err
+}
+
+// CellErrors is the list of cells which can't be converted
+type CellErrors []*CellError
+
+func (e CellErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// MissingColumnsError lists required columns absent from the sheet header
+type MissingColumnsError struct {
+	SheetName string
+	Columns   []string
+}
+
+func (e *MissingColumnsError) Error() string {
+	return fmt.Sprintf("sheet %s: missing required columns: %s", e.SheetName, strings.Join(e.Columns, ", "))
+}
+
+// TamperedRowsError lists rows whose locked cells were changed after Write
+type TamperedRowsError struct {
+	SheetName string
+	// Rows are row indexes
+	Rows []int
+}
+
+func (e *TamperedRowsError) Error() string {
+	rows := make([]string, len(e.Rows))
+	for i, row := range e.Rows {
+		rows[i] = strconv.Itoa(row)
+	}
+	return fmt.Sprintf("sheet %s: locked cells changed in rows %s", e.SheetName, strings.Join(rows, ", "))
+}
+
+// SchemaError lists differences of the sheet from the schema saved by Write
+type SchemaError struct {
+	SheetName string
+	Problems  []string
+}
+
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("sheet %s doesn't match the schema: %s", e.SheetName, strings.Join(e.Problems, "; "))
+}
+
+// DuplicateColumnError reports fields written under the same header or to the same column
+type DuplicateColumnError struct {
+	// Column is the header or the letter of the column set by col or index tags
+	Column string
+	Fields []string
+}
+
+func (e *DuplicateColumnError) Error() string {
+	return fmt.Sprintf("column %s is written by fields %s", e.Column, strings.Join(e.Fields, ", "))
+}
@@ -0,0 +1,77 @@
+package xlsx
+
+import (
+	"reflect"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// exampleMarker is the header of the hidden column marking the example row, Unmarshal skips marked rows
+const exampleMarker = "#example"
+
+// exampleColor is the font color of example values
+const exampleColor = "#808080"
+
+// writeExampleRow writes values of example tags converted to field types in gray italics,
+// the row is marked in the hidden column after the last column
+func writeExampleRow(file *excelize.File, sheetName string, columns []column, headerRow int, o WriteOptions) error {
+	rowIdx := headerRow + 1
+	written := false
+	for _, c := range columns {
+		example := getTag(c.field, "example")
+		if example == "" {
+			continue
+		}
+		style, _, err := o.columnStyle(c)
+		if err != nil {
+			return err
+		}
+		style.Color, style.Italic = exampleColor, true
+		styleID, err := style.newStyle(file, false)
+		if err != nil {
+			return err
+		}
+
+		cell := GetCellName(c.index, rowIdx)
+		if err := setCellValue(file, sheetName, cell, exampleValue(c.field, example)); err != nil {
+			return err
+		}
+		if err := file.SetCellStyle(sheetName, cell, cell, styleID); err != nil {
+			return err
+		}
+		written = true
+	}
+	if !written {
+		return nil
+	}
+
+	columnIdx := lastColumnIndex(columns) + 1
+	for _, row := range []int{headerRow, rowIdx} {
+		if err := file.SetCellStr(sheetName, GetCellName(columnIdx, row), exampleMarker); err != nil {
+			return err
+		}
+	}
+	return file.SetColVisible(sheetName, getColumnLetter(columnIdx), false)
+}
+
+// exampleValue returns the example as the field would be written, e.g. "12.50" of a float64 field is a number,
+// examples which aren't values of the field type stay text
+func exampleValue(field reflect.StructField, example string) interface{} {
+	value := reflect.New(field.Type).Elem()
+	if err := convertCell(value, example, example, excelize.CellTypeSharedString, false); err != nil {
+		return example
+	}
+	cellValue, err := getCellValue(field, value)
+	if err != nil {
+		return example
+	}
+	return cellValue
+}
+
+// isExample reports whether the row is the example row of a template, see WriteTemplateHeaders
+func (h *header) isExample(raw []string) bool {
+	if h == nil || h.example < 0 {
+		return false
+	}
+	return cellAt(raw, h.example) == exampleMarker
+}
@@ -0,0 +1,115 @@
+package xlsx
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// WithCopyRowStyles makes WriteIntoTemplate copy styles and the height of the first data row to all rows below it
+func WithCopyRowStyles() Option {
+	return func(o *options) {
+		o.write.CopyRowStyles = true
+	}
+}
+
+// WriteIntoTemplate fills the data region of the existing sheet like a branded template starting at startCell like "B5",
+// the first column goes to the start column and others follow it like Write puts them after the column A
+// Only data cells are written, headers, styles, images and formulas of the template are kept,
+// cells keep their own styles unless WithCopyRowStyles is given
+// Rows below the region are overwritten if data is longer, rows aren't inserted
+// Formula and hyperlink tags are supported, see Write
+func WriteIntoTemplate(file *excelize.File, sheetName string, startCell string, data interface{}, opts ...Option) error {
+	o := newOptions(opts)
+
+	slice := reflect.ValueOf(data)
+	if slice.Kind() != reflect.Slice {
+		return fmt.Errorf("slice only is allowed")
+	}
+	elemType := slice.Type().Elem()
+	if elemType.Kind() != reflect.Struct {
+		return fmt.Errorf("slice of structs only is allowed")
+	}
+	if index, err := file.GetSheetIndex(sheetName); err != nil || index < 0 {
+		return fmt.Errorf("sheet %q not found", sheetName)
+	}
+	startCol, startRow, err := excelize.CellNameToCoordinates(startCell)
+	if err != nil {
+		return err
+	}
+
+	columns := append([]column{}, getColumns(elemType)...)
+	if err := checkColumns(columns); err != nil {
+		return err
+	}
+	for i := range columns {
+		columns[i].index += startCol - 1
+	}
+
+	var styles map[int]int
+	var height float64
+	if o.write.CopyRowStyles {
+		styles = map[int]int{}
+		for _, c := range columns {
+			if styles[c.index], err = file.GetCellStyle(sheetName, GetCellName(c.index, startRow)); err != nil {
+				return err
+			}
+		}
+		if height, err = file.GetRowHeight(sheetName, startRow); err != nil {
+			return err
+		}
+	}
+
+	for i := 0; i < slice.Len(); i++ {
+		rowIdx := startRow + i
+		if err := fillRow(file, sheetName, slice.Index(i), rowIdx, columns); err != nil {
+			return err
+		}
+		if styles == nil || rowIdx == startRow {
+			continue
+		}
+		for _, c := range columns {
+			cell := GetCellName(c.index, rowIdx)
+			if err := file.SetCellStyle(sheetName, cell, cell, styles[c.index]); err != nil {
+				return err
+			}
+		}
+		if err := file.SetRowHeight(sheetName, rowIdx, height); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fillRow writes values, formulas and links of the element to the row keeping styles of cells
+func fillRow(file *excelize.File, sheetName string, element reflect.Value, rowIdx int, columns []column) error {
+	for _, c := range columns {
+		value, err := c.cellValue(element)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", c.field.Name, err)
+		}
+		value, link, err := c.hyperlink(element, value)
+		if err != nil {
+			return err
+		}
+
+		cell := GetCellName(c.index, rowIdx)
+		formula, err := c.formula(columns, rowIdx)
+		if err != nil {
+			return err
+		}
+		if formula != "" {
+			err = file.SetCellFormula(sheetName, cell, formula)
+		} else {
+			err = setCellValue(file, sheetName, cell, value)
+		}
+		if err != nil {
+			return err
+		}
+		if err := setHyperlink(file, sheetName, cell, link); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package xlsx
+
+import (
+	"github.com/xuri/excelize/v2"
+)
+
+// WithFreezeHeader keeps the header visible while scrolling, rows above it too
+func WithFreezeHeader() Option {
+	return func(o *options) {
+		o.write.FreezeHeader = true
+	}
+}
+
+// WithAutoFilter adds filter buttons over all data rows to the header, or to the totals row below it
+// WriteStream ignores it, excelize keeps streamed sheets as they are flushed
+func WithAutoFilter() Option {
+	return func(o *options) {
+		o.write.AutoFilter = true
+	}
+}
+
+// freezePanes returns panes freezing rows above dataRow
+func freezePanes(dataRow int) *excelize.Panes {
+	return &excelize.Panes{
+		Freeze:      true,
+		YSplit:      dataRow - 1,
+		TopLeftCell: GetCellName(0, dataRow),
+		ActivePane:  "bottomLeft",
+	}
+}
+
+// setAutoFilter filters data rows below filterRow to lastRow, filter buttons are in filterRow
+// It's the row right above the data, so rows between the header and the data like totals aren't filtered
+func setAutoFilter(file *excelize.File, sheetName string, columns []column, filterRow int, lastRow int) error {
+	rangeRef := GetCellName(0, filterRow) + ":" + GetCellName(lastColumnIndex(columns), lastRow)
+	return file.AutoFilter(sheetName, rangeRef, nil)
+}
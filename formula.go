@@ -0,0 +1,53 @@
+package xlsx
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// formula returns the formula of the formula tag in the row without the leading "=", empty for other columns
+// {row} is replaced with the row index, {Name} with the cell of the column named by the header or the field name,
+// e.g. formula:=B{row}*C{row} or formula:={Price}*{Qty}
+func (c column) formula(columns []column, rowIdx int) (string, error) {
+	template := strings.TrimPrefix(getTag(c.field, "formula"), "=")
+	if template == "" {
+		return "", nil
+	}
+
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(template, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(template[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+		b.WriteString(template[:start])
+
+		name := template[start+1 : end]
+		if name == "row" {
+			b.WriteString(strconv.Itoa(rowIdx))
+		} else if ref, ok := findFormulaColumn(columns, name); ok {
+			b.WriteString(GetCellName(ref.index, rowIdx))
+		} else {
+			return "", fmt.Errorf("formula of field %s: unknown column {%s}", c.field.Name, name)
+		}
+		template = template[end+1:]
+	}
+	b.WriteString(template)
+	return b.String(), nil
+}
+
+// findFormulaColumn returns the column with the header or the field name
+func findFormulaColumn(columns []column, name string) (column, bool) {
+	for _, c := range columns {
+		if c.name == name || (c.restKey == "" && c.field.Name == name) {
+			return c, true
+		}
+	}
+	return column{}, false
+}
@@ -0,0 +1,49 @@
+package xlsx
+
+import (
+	"strings"
+)
+
+// WithFuzzyHeaders matches headers with up to maxDistance typos like "Emai" with column names,
+// letter case, spaces and punctuation aren't typos
+// Headers are matched with the closest column name, matches go to the import report, see WithImportReport
+func WithFuzzyHeaders(maxDistance int) Option {
+	return func(o *options) {
+		o.read.FuzzyHeaders = maxDistance
+	}
+}
+
+// findFuzzy returns the header closest to an alias of the column within the allowed number of typos
+func (h *header) findFuzzy(c column) (headerName, bool) {
+	best, bestDistance, bestAlias := headerName{}, h.maxDistance+1, ""
+	for _, alias := range c.aliases {
+		aliasKey := similarityKey(alias)
+		for _, hn := range h.names {
+			if strings.HasPrefix(hn.name, "#") {
+				continue
+			}
+			if distance := levenshtein(similarityKey(hn.name), aliasKey); distance < bestDistance {
+				best, bestDistance, bestAlias = hn, distance, alias
+			}
+		}
+	}
+	if bestDistance > h.maxDistance {
+		return headerName{}, false
+	}
+
+	if h.report != nil {
+		// Columns are looked up more than once, e.g. for required ones
+		for _, reported := range h.report.FuzzyHeaders {
+			if reported.Header == best.name && reported.Field == c.field.Name {
+				return best, true
+			}
+		}
+		h.report.FuzzyHeaders = append(h.report.FuzzyHeaders, FuzzyHeader{
+			Header:   best.name,
+			Column:   bestAlias,
+			Field:    c.field.Name,
+			Distance: bestDistance,
+		})
+	}
+	return best, true
+}
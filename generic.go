@@ -0,0 +1,25 @@
+package xlsx
+
+import (
+	"github.com/xuri/excelize/v2"
+)
+
+// Read reads the first sheet of the file into a slice of T, see Unmarshal
+// T is a struct or a pointer to a struct, rows read before an error are returned with it
+func Read[T any](file *excelize.File, opts ...Option) ([]T, error) {
+	var rows []T
+	err := Unmarshal(file, &rows, opts...)
+	return rows, err
+}
+
+// ReadSheet reads the sheet with the given name into a slice of T, see Unmarshal
+func ReadSheet[T any](file *excelize.File, sheetName string, opts ...Option) ([]T, error) {
+	var rows []T
+	err := UnmarshalSheet(file, sheetName, &rows, opts...)
+	return rows, err
+}
+
+// WriteRows adds new sheet with rows, see Write
+func WriteRows[T any](file *excelize.File, sheetName string, rows []T, opts ...Option) error {
+	return Write(file, sheetName, rows, opts...)
+}
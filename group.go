@@ -0,0 +1,173 @@
+package xlsx
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Grouping configures outline groups of consecutive rows with the same GroupBy field value
+// Every group gets a summary row marked in a hidden column, so Unmarshal skips it
+type Grouping struct {
+	// GroupBy is the struct field name
+	GroupBy string
+	// SummaryAbove puts summary rows above their groups, below by default
+	SummaryAbove bool
+	// Aggregates maps field names to aggregations of the summary row: sum, count, avg, min, max or first
+	// The summary tag does the same, e.g. `xlsx:"summary:sum"`
+	// The GroupBy column shows the first value, other columns are empty unless set
+	Aggregates map[string]string
+}
+
+// WithGrouping groups rows into outline groups with summary rows
+func WithGrouping(grouping Grouping) Option {
+	return func(o *options) {
+		o.write.Grouping = grouping
+	}
+}
+
+// subtotalFunctions maps aggregations to SUBTOTAL function numbers
+var subtotalFunctions = map[string]int{
+	"avg":   1,
+	"count": 3,
+	"max":   4,
+	"min":   5,
+	"sum":   9,
+}
+
+// writeGroups writes elements in outline groups starting from firstRow and returns the number of written rows
+func writeGroups(file *excelize.File, sheetName string, slice reflect.Value, columns []column, firstRow int, styles *sheetStyles, o *options) (int, error) {
+	g := o.write.Grouping
+
+	var groupColumn *column
+	for i := range columns {
+		if columns[i].field.Name == g.GroupBy {
+			groupColumn = &columns[i]
+		}
+	}
+	if groupColumn == nil {
+		return 0, fmt.Errorf("group by field %s is not written", g.GroupBy)
+	}
+
+	if g.SummaryAbove {
+		below := false
+		err := file.SetSheetProps(sheetName, &excelize.SheetPropsOptions{OutlineSummaryBelow: &below})
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	font := o.write.BodyStyle.font()
+	font.Bold = true
+	summaryStyle, _ := file.NewStyle(&excelize.Style{Font: &font})
+
+	groupKey := func(rowi int) string {
+		value, _ := groupColumn.cellValue(slice.Index(rowi))
+		return fmt.Sprint(value)
+	}
+
+	rowIdx := firstRow
+	for from := 0; from < slice.Len(); {
+		to := from + 1
+		for to < slice.Len() && groupKey(to) == groupKey(from) {
+			to++
+		}
+
+		detailFrom := rowIdx
+		if g.SummaryAbove {
+			detailFrom++
+		}
+		detailTo := detailFrom + to - from - 1
+
+		if g.SummaryAbove {
+			err := writeSummaryRow(file, sheetName, slice.Index(from), rowIdx, detailFrom, detailTo, columns, g, summaryStyle)
+			if err != nil {
+				return 0, err
+			}
+			rowIdx++
+		}
+
+		for rowi := from; rowi < to; rowi++ {
+			if err := writeRow(file, sheetName, slice.Index(rowi), rowi, rowIdx, columns, styles, o); err != nil {
+				return 0, err
+			}
+			if err := file.SetRowOutlineLevel(sheetName, rowIdx, 1); err != nil {
+				return 0, err
+			}
+			rowIdx++
+		}
+
+		if !g.SummaryAbove {
+			err := writeSummaryRow(file, sheetName, slice.Index(from), rowIdx, detailFrom, detailTo, columns, g, summaryStyle)
+			if err != nil {
+				return 0, err
+			}
+			rowIdx++
+		}
+
+		from = to
+	}
+	return rowIdx - firstRow, nil
+}
+
+// groupedRows returns the number of rows writeGroups writes, elements and summary rows of their groups
+func groupedRows(slice reflect.Value, columns []column, g Grouping) int {
+	for _, c := range columns {
+		if c.field.Name != g.GroupBy {
+			continue
+		}
+		rows := slice.Len()
+		var last string
+		for i := 0; i < slice.Len(); i++ {
+			value, _ := c.cellValue(slice.Index(i))
+			if key := fmt.Sprint(value); i == 0 || key != last {
+				rows++
+				last = key
+			}
+		}
+		return rows
+	}
+	return slice.Len()
+}
+
+// writeSummaryRow writes aggregations of detail rows from detailFrom to detailTo
+func writeSummaryRow(file *excelize.File, sheetName string, first reflect.Value, rowIdx int, detailFrom int, detailTo int, columns []column, g Grouping, style int) error {
+	file.SetRowHeight(sheetName, rowIdx, 18)
+
+	for _, c := range columns {
+		aggregate, ok := g.Aggregates[c.field.Name]
+		if !ok {
+			aggregate = getTag(c.field, "summary")
+		}
+		if aggregate == "" && c.field.Name == g.GroupBy {
+			aggregate = "first"
+		}
+
+		cell := GetCellName(c.index, rowIdx)
+		switch aggregate {
+		case "":
+			continue
+		case "first":
+			cellValue, err := c.cellValue(first)
+			if err != nil {
+				return fmt.Errorf("field %s: %w", c.field.Name, err)
+			}
+			if err := file.SetCellValue(sheetName, cell, cellValue); err != nil {
+				return err
+			}
+		default:
+			function, ok := subtotalFunctions[aggregate]
+			if !ok {
+				return fmt.Errorf("field %s: unknown aggregation %q", c.field.Name, aggregate)
+			}
+			from, to := GetCellName(c.index, detailFrom), GetCellName(c.index, detailTo)
+			formula := fmt.Sprintf("SUBTOTAL(%d,%s:%s)", function, from, to)
+			if err := file.SetCellFormula(sheetName, cell, formula); err != nil {
+				return err
+			}
+		}
+		file.SetCellStyle(sheetName, cell, cell, style)
+	}
+	return markRow(file, sheetName, columns, rowIdx, summaryRowKind)
+}
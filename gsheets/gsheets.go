@@ -0,0 +1,175 @@
+// Package gsheets translates tagged structs to and from ValueRange of the Google Sheets API,
+// so the same structs are written to .xlsx files and live spreadsheets
+//
+//	vr, err := gsheets.Marshal("Dogs", dogs)
+//	...
+//	_, err = srv.Spreadsheets.Values.Update(id, vr.Range, &sheets.ValueRange{
+//		Range: vr.Range, MajorDimension: vr.MajorDimension, Values: vr.Values,
+//	}).ValueInputOption("USER_ENTERED").Do()
+//
+//	resp, err := srv.Spreadsheets.Values.Get(id, "Dogs").ValueRenderOption("UNFORMATTED_VALUE").Do()
+//	...
+//	err = gsheets.Unmarshal(&gsheets.ValueRange{Range: resp.Range, MajorDimension: resp.MajorDimension, Values: resp.Values}, &dogs)
+package gsheets
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/boltegg/xlsx"
+	"github.com/xuri/excelize/v2"
+)
+
+// Major dimensions of ValueRange
+const (
+	Rows    = "ROWS"
+	Columns = "COLUMNS"
+)
+
+// ValueRange has the fields of sheets.ValueRange of google.golang.org/api/sheets/v4
+// and its JSON encoding, values of one are assigned to the other field by field
+type ValueRange struct {
+	// Range is in A1 notation, e.g. "'Dogs'!A1"
+	Range string `json:"range,omitempty"`
+	// MajorDimension is Rows or Columns, Rows if empty
+	MajorDimension string `json:"majorDimension,omitempty"`
+	// Values are strings, float64 and bool, the first row is the header
+	Values [][]interface{} `json:"values,omitempty"`
+}
+
+// Marshal returns values of data with the header starting at A1 of the sheet, see xlsx.Values
+// Dates are strings parsed by the USER_ENTERED value input option
+func Marshal(sheetName string, data interface{}, opts ...xlsx.Option) (*ValueRange, error) {
+	rows, err := xlsx.Values(data, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		for i, value := range row {
+			row[i] = jsonValue(value)
+		}
+	}
+	return &ValueRange{
+		Range:          "'" + strings.ReplaceAll(sheetName, "'", "''") + "'!A1",
+		MajorDimension: Rows,
+		Values:         rows,
+	}, nil
+}
+
+// jsonValue converts the cell value to the type accepted by the API
+func jsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string, bool, float64:
+		return v
+	case json.Number:
+		// Big numbers and decimals keep their digits in the request JSON
+		return v
+	case float32:
+		return float64(v)
+	case int:
+		return float64(v)
+	case int8:
+		return float64(v)
+	case int16:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint:
+		return float64(v)
+	case uint8:
+		return float64(v)
+	case uint16:
+		return float64(v)
+	case uint32:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	}
+	return fmt.Sprint(value)
+}
+
+// Unmarshal reads values of the range into v like xlsx.Unmarshal, the first row of values is the header
+// Values read with the UNFORMATTED_VALUE render option keep numbers and booleans typed,
+// formatted strings are converted by the field types
+func Unmarshal(vr *ValueRange, v interface{}, opts ...xlsx.Option) error {
+	sheetName := rangeSheet(vr.Range)
+	return xlsx.UnmarshalSource(Source(vr), sheetName, v, opts...)
+}
+
+// Source returns values of the range as the cell source of its sheet, values[0][0] is A1
+func Source(vr *ValueRange) *xlsx.MemorySource {
+	values := vr.Values
+	if vr.MajorDimension == Columns {
+		values = transpose(values)
+	}
+
+	sheetName := rangeSheet(vr.Range)
+	raw := map[string]string{}
+	formatted := map[string]string{}
+	types := map[string]excelize.CellType{}
+	for rowi, row := range values {
+		for columni, value := range row {
+			cell := xlsx.GetCellName(columni, rowi+1)
+			switch value := value.(type) {
+			case nil:
+			case bool:
+				raw[cell], formatted[cell] = "0", "FALSE"
+				if value {
+					raw[cell], formatted[cell] = "1", "TRUE"
+				}
+				types[cell] = excelize.CellTypeBool
+			case float64:
+				raw[cell] = strconv.FormatFloat(value, 'f', -1, 64)
+				types[cell] = excelize.CellTypeNumber
+			default:
+				if s := fmt.Sprint(value); s != "" {
+					raw[cell] = s
+				}
+			}
+		}
+	}
+	return &xlsx.MemorySource{
+		Raw:       map[string]map[string]string{sheetName: raw},
+		Formatted: map[string]map[string]string{sheetName: formatted},
+		Types:     map[string]map[string]excelize.CellType{sheetName: types},
+	}
+}
+
+// rangeSheet returns the unquoted sheet name of the A1 range, "Sheet1" if the range has none
+func rangeSheet(a1 string) string {
+	i := strings.LastIndex(a1, "!")
+	if i < 0 {
+		if a1 == "" {
+			return "Sheet1"
+		}
+		// The range is the sheet name only
+		i = len(a1)
+	}
+	name := a1[:i]
+	if len(name) >= 2 && strings.HasPrefix(name, "'") && strings.HasSuffix(name, "'") {
+		name = strings.ReplaceAll(name[1:len(name)-1], "''", "'")
+	}
+	return name
+}
+
+func transpose(values [][]interface{}) [][]interface{} {
+	var rows [][]interface{}
+	for columni, column := range values {
+		for rowi, value := range column {
+			for len(rows) <= rowi {
+				rows = append(rows, nil)
+			}
+			for len(rows[rowi]) < columni {
+				rows[rowi] = append(rows[rowi], nil)
+			}
+			rows[rowi] = append(rows[rowi], value)
+		}
+	}
+	return rows
+}
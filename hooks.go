@@ -0,0 +1,42 @@
+package xlsx
+
+import (
+	"reflect"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// WriteContext describes the row or the cell being written, it is passed to write hooks
+type WriteContext struct {
+	File      *excelize.File
+	SheetName string
+	// Index is the index of the element among the rows of the sheet
+	Index int
+	// Element is the written slice element
+	Element interface{}
+	// RowIdx is the sheet row index
+	RowIdx int
+	// ColumnIdx is zero based column index, it is set for field hooks only
+	ColumnIdx int
+	// Cell is the cell name, it is set for field hooks only
+	Cell string
+	// Field is the struct field, it is set for field hooks only
+	Field reflect.StructField
+	// Value is the value written to the cell, a field hook can replace it
+	Value interface{}
+}
+
+// WithFieldHook sets hook called before every cell is written
+// The hook can change ctx.Value to write another value
+func WithFieldHook(fn func(ctx *WriteContext) error) Option {
+	return func(o *options) {
+		o.write.OnField = fn
+	}
+}
+
+// WithRowHook sets hook called after every data row is written
+func WithRowHook(fn func(ctx *WriteContext) error) Option {
+	return func(o *options) {
+		o.write.OnRow = fn
+	}
+}
@@ -0,0 +1,126 @@
+package xlsx
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// linkColor is the font color of link cells, the same as links of the index sheet
+const linkColor = "#1265BE"
+
+// isHyperlinkField reports whether cells of the field are links, see column.hyperlink
+func isHyperlinkField(field reflect.StructField) bool {
+	return getTagBool(field, "hyperlink") || getTag(field, "hyperlink_url") != "" || getTag(field, "hyperlink_text") != ""
+}
+
+// hyperlink returns the cell value and the link target of the column cell, the target is empty without a link
+// The hyperlink tag links to the value itself, hyperlink_url:URL links to the URL field of the same struct
+// and hyperlink_text:Title shows the Title field linking to the value
+func (c column) hyperlink(element reflect.Value, value interface{}) (interface{}, string, error) {
+	if c.restKey != "" {
+		return value, "", nil
+	}
+	switch {
+	case getTagBool(c.field, "hyperlink"):
+		return value, fmt.Sprint(value), nil
+	case getTag(c.field, "hyperlink_url") != "":
+		link, err := siblingText(element, c.field, getTag(c.field, "hyperlink_url"))
+		return value, link, err
+	case getTag(c.field, "hyperlink_text") != "":
+		text, err := siblingText(element, c.field, getTag(c.field, "hyperlink_text"))
+		return text, fmt.Sprint(value), err
+	}
+	return value, "", nil
+}
+
+// siblingText returns the text of the field with the name in the struct of the field, empty for nil pointers
+func siblingText(element reflect.Value, field reflect.StructField, name string) (string, error) {
+	sibling, err := siblingField(element, field, name)
+	if err != nil {
+		return "", err
+	}
+	sibling = reflect.Indirect(sibling)
+	if !sibling.IsValid() {
+		return "", nil
+	}
+	return fmt.Sprint(sibling.Interface()), nil
+}
+
+// siblingField returns the field with the name in the struct of the field
+func siblingField(element reflect.Value, field reflect.StructField, name string) (reflect.Value, error) {
+	parent := element
+	if len(field.Index) > 1 {
+		parent = element.FieldByIndex(field.Index[:len(field.Index)-1])
+	}
+	sibling := parent.FieldByName(name)
+	if !sibling.IsValid() || !sibling.CanInterface() {
+		return reflect.Value{}, fmt.Errorf("field %s: no exported field %s for the link", field.Name, name)
+	}
+	return sibling, nil
+}
+
+// setHyperlink links the cell to the target, see hyperlinkTarget
+func setHyperlink(file *excelize.File, sheetName string, cell string, target string) error {
+	target, linkType := hyperlinkTarget(target)
+	if target == "" {
+		return nil
+	}
+	return file.SetCellHyperLink(sheetName, cell, target, linkType)
+}
+
+// hyperlinkTarget returns the link and its excelize type, e-mail addresses become mailto: links
+// and targets like "#Sheet2!A1" are places in the workbook
+func hyperlinkTarget(target string) (string, string) {
+	target = strings.TrimSpace(target)
+	switch {
+	case strings.HasPrefix(target, "#"):
+		return target[1:], "Location"
+	case strings.Contains(target, "@") && !strings.Contains(target, ":"):
+		return "mailto:" + target, "External"
+	}
+	return target, "External"
+}
+
+// hyperlinkFormula returns the HYPERLINK formula of spreadsheets which have no link cells
+func hyperlinkFormula(text interface{}, target string) string {
+	quote := func(s string) string {
+		return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+	}
+	return fmt.Sprintf("=HYPERLINK(%s,%s)", quote(target), quote(fmt.Sprint(text)))
+}
+
+// hyperlink reads the link of the column cell like Write puts it
+// The field with the hyperlink tag gets the target instead of the text, the URL field of hyperlink_url gets the target
+// and the Title field of hyperlink_text gets the text while the field gets the target
+// It returns the target and true if the target replaces the cell value
+func (d *cellDecoder) hyperlink(element reflect.Value, c column, text string) (string, bool, error) {
+	ok, target, err := d.file.GetCellHyperLink(d.sheetName, GetCellName(c.index, d.rows.rowIdx))
+	if err != nil || !ok {
+		return "", false, err
+	}
+	// Written e-mail addresses come back as they were
+	if strings.TrimPrefix(target, "mailto:") == text {
+		target = text
+	}
+
+	switch {
+	case getTagBool(c.field, "hyperlink"):
+		return target, true, nil
+	case getTag(c.field, "hyperlink_url") != "":
+		sibling, err := siblingField(element, c.field, getTag(c.field, "hyperlink_url"))
+		if err != nil {
+			return "", false, err
+		}
+		return "", false, convertCell(sibling, target, target, excelize.CellTypeSharedString, d.date1904)
+	case getTag(c.field, "hyperlink_text") != "":
+		sibling, err := siblingField(element, c.field, getTag(c.field, "hyperlink_text"))
+		if err != nil {
+			return "", false, err
+		}
+		return target, true, convertCell(sibling, text, text, excelize.CellTypeSharedString, d.date1904)
+	}
+	return "", false, nil
+}
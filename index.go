@@ -0,0 +1,116 @@
+package xlsx
+
+import (
+	"strconv"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ensureSheet creates the sheet if it doesn't exist
+func ensureSheet(file *excelize.File, sheetName string) {
+	if index, err := file.GetSheetIndex(sheetName); err == nil && index < 0 {
+		file.NewSheet(sheetName)
+	}
+}
+
+// writeIndexSheet lists all sheets of the file except the index with links and data row counts
+// dataRows are counts of sheets written by the call, other sheets keep counts listed before
+func writeIndexSheet(file *excelize.File, indexSheet string, dataRows map[string]int) error {
+	ensureSheet(file, indexSheet)
+
+	font := defaultFont
+	style, _ := file.NewStyle(&excelize.Style{Font: &font})
+	linkStyle, _ := newLinkStyle(file)
+
+	oldRows, err := file.GetRows(indexSheet)
+	if err != nil {
+		return err
+	}
+
+	listed := map[string]int{}
+	for _, row := range oldRows {
+		if len(row) < 2 {
+			continue
+		}
+		if count, err := strconv.Atoi(row[1]); err == nil {
+			listed[row[0]] = count
+		}
+	}
+
+	rows := [][]interface{}{{"Sheet", "Rows"}}
+	for _, sheetName := range file.GetSheetList() {
+		if sheetName == indexSheet || sheetName == schemaSheet {
+			continue
+		}
+		count, ok := dataRows[sheetName]
+		if !ok {
+			count, ok = listed[sheetName]
+		}
+		if !ok {
+			if count, err = countDataRows(file, sheetName); err != nil {
+				return err
+			}
+		}
+		rows = append(rows, []interface{}{sheetName, count})
+	}
+
+	for rowi, row := range rows {
+		if err := file.SetSheetRow(indexSheet, GetCellName(0, rowi+1), &row); err != nil {
+			return err
+		}
+		file.SetCellStyle(indexSheet, GetCellName(0, rowi+1), GetCellName(1, rowi+1), style)
+		file.SetRowHeight(indexSheet, rowi+1, 18)
+
+		if rowi > 0 {
+			link := quoteSheetName(row[0].(string)) + "!A1"
+			if err := file.SetCellHyperLink(indexSheet, GetCellName(0, rowi+1), link, "Location"); err != nil {
+				return err
+			}
+			file.SetCellStyle(indexSheet, GetCellName(0, rowi+1), GetCellName(0, rowi+1), linkStyle)
+		}
+	}
+
+	// Remove sheets listed before which are gone now
+	for rowIdx := len(oldRows); rowIdx > len(rows); rowIdx-- {
+		if err := file.RemoveRow(indexSheet, rowIdx); err != nil {
+			return err
+		}
+	}
+
+	file.SetColWidth(indexSheet, "A", "A", 30)
+	return nil
+}
+
+// writeBackLink puts the link to the index sheet to the header row cell
+func writeBackLink(file *excelize.File, sheetName string, columnIdx int, headerRow int, indexSheet string, text string) error {
+	cell := GetCellName(columnIdx, headerRow)
+
+	if err := file.SetCellValue(sheetName, cell, text); err != nil {
+		return err
+	}
+	if err := file.SetCellHyperLink(sheetName, cell, quoteSheetName(indexSheet)+"!A1", "Location"); err != nil {
+		return err
+	}
+	linkStyle, _ := newLinkStyle(file)
+	return file.SetCellStyle(sheetName, cell, cell, linkStyle)
+}
+
+// countDataRows returns the number of rows below the header of sheets not written by Write
+func countDataRows(file *excelize.File, sheetName string) (int, error) {
+	rows, err := file.GetRows(sheetName)
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) < 2 {
+		return 0, nil
+	}
+	return len(rows) - 1, nil
+}
+
+// newLinkStyle returns style of hyperlink cells
+func newLinkStyle(file *excelize.File) (int, error) {
+	font := defaultFont
+	font.Color = linkColor
+	font.Underline = "single"
+	return file.NewStyle(&excelize.Style{Font: &font})
+}
@@ -0,0 +1,37 @@
+package xlsx
+
+import (
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestIndexSheetCountsDataRows(t *testing.T) {
+	type row struct {
+		Name string
+		Qty  int
+	}
+	file := excelize.NewFile()
+	err := Write(file, "Data", []row{{"a", 1}, {"b", 2}}, WithBanner("Report"), WithFooterRows([]string{"x", "y"}),
+		WithTotals(map[string]string{"Qty": "sum"}), WithTypeRow(), WithIndexSheet("Index"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Write(file, "Other", []row{{"c", 3}}, WithIndexSheet("Index")); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := file.GetRows("Index")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{{"Sheet", "Rows"}, {"Data", "2"}, {"Other", "1"}}
+	if len(rows) != len(want) {
+		t.Fatalf("got %v, want %v", rows, want)
+	}
+	for i := range want {
+		if rows[i][0] != want[i][0] || rows[i][1] != want[i][1] {
+			t.Fatalf("got %v, want %v", rows, want)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package xlsx
+
+// Layout describes where Write has put the data
+type Layout struct {
+	SheetName string
+	// Header is the range of the header row
+	Header CellRange
+	// Totals is the range of the row below the header, it is zero if there are no totals
+	Totals CellRange
+	// Data is the range of the data rows, it has no rows if the slice is empty
+	Data CellRange
+	// Footer is the range of footer rows below the data, it is zero if there is no footer
+	Footer  CellRange
+	Columns []LayoutColumn
+}
+
+// LayoutColumn describes the sheet column of a struct field
+type LayoutColumn struct {
+	Field  string
+	Name   string
+	Index  int
+	Letter string
+}
+
+// totalsRow is 0 if there are no totals
+func newLayout(sheetName string, columns []column, headerRow int, totalsRow int, dataRow int, rows int) Layout {
+	l := Layout{SheetName: sheetName}
+	for _, c := range columns {
+		l.Columns = append(l.Columns, LayoutColumn{
+			Field:  c.field.Name,
+			Name:   c.name,
+			Index:  c.index,
+			Letter: getColumnLetter(c.index),
+		})
+	}
+
+	lastColumn := lastColumnIndex(columns)
+	l.Header = CellRange{FromColumn: 0, FromRow: headerRow, ToColumn: lastColumn, ToRow: headerRow}
+	l.Data = CellRange{FromColumn: 0, FromRow: dataRow, ToColumn: lastColumn, ToRow: dataRow + rows - 1}
+	if totalsRow > 0 {
+		l.Totals = CellRange{FromColumn: 0, FromRow: totalsRow, ToColumn: lastColumn, ToRow: totalsRow}
+	}
+	return l
+}
+
+// Column returns the column of the struct field
+func (l Layout) Column(field string) (LayoutColumn, bool) {
+	for _, c := range l.Columns {
+		if c.Field == field {
+			return c, true
+		}
+	}
+	return LayoutColumn{}, false
+}
+
+// ColumnRange returns the data range of the struct field column
+func (l Layout) ColumnRange(field string) (CellRange, bool) {
+	c, ok := l.Column(field)
+	if !ok {
+		return CellRange{}, false
+	}
+	return CellRange{FromColumn: c.Index, FromRow: l.Data.FromRow, ToColumn: c.Index, ToRow: l.Data.ToRow}, true
+}
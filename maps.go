@@ -0,0 +1,141 @@
+package xlsx
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// isMapElem reports whether Unmarshal reads rows into maps of the type keyed by header
+// Values of map[string]string are formatted cells, values of map[string]interface{} are
+// float64 for numbers, bool for booleans and formatted strings for other cells
+func isMapElem(t reflect.Type) bool {
+	return t == reflect.TypeOf(map[string]string{}) || t == reflect.TypeOf(map[string]interface{}{})
+}
+
+// unmarshalMaps appends rows of the sheet to the slice of maps
+// file is nil if rows are read from another CellSource
+func unmarshalMaps(file *excelize.File, rows *rowReader, sheetName string, slice reflect.Value, o UnmarshalOptions) error {
+	typed := slice.Type().Elem() == reflect.TypeOf(map[string]interface{}{})
+	if err := o.prepareRows(rows, file); err != nil {
+		return err
+	}
+	p, err := newReadProgress(file, sheetName, rows, o.OnProgress)
+	if err != nil {
+		return err
+	}
+
+	var h *header
+	emptyRows := 0
+	for rows.Next() && emptyRows < o.EmptyRowGap {
+		p.set(rows.rowIdx)
+		if rows.rowIdx < o.HeaderRow || (rows.rowIdx > o.HeaderRow && rows.rowIdx < o.DataStartRow) {
+			continue
+		}
+
+		raw, formatted, err := rows.Row()
+		if err != nil {
+			return err
+		}
+
+		if rows.rowIdx == o.HeaderRow {
+			h = parseHeader(formatted, o)
+			continue
+		}
+		if h == nil {
+			p.finish()
+			return nil
+		}
+		if rows.rowIdx == o.HeaderRow+1 {
+			if _, ok := parseTypeRow(file, sheetName, rows.rowIdx, raw); ok {
+				continue
+			}
+		}
+		kind := h.rowKind(raw)
+		if kind == footerRowKind {
+			break
+		}
+		if h.isExample(raw) || kind != "" {
+			continue
+		}
+
+		element := reflect.MakeMap(slice.Type().Elem())
+		for _, hn := range h.names {
+			key := reflect.ValueOf(hn.name)
+			rawValue := cellAt(raw, hn.columnIdx)
+			if rawValue == "" || strings.HasPrefix(hn.name, "#") || element.MapIndex(key).IsValid() {
+				continue
+			}
+
+			formattedValue := cellAt(formatted, hn.columnIdx)
+			var value interface{} = formattedValue
+			if typed {
+				switch rows.cellType(hn.columnIdx, rawValue, formattedValue) {
+				case excelize.CellTypeNumber:
+					value, _ = strconv.ParseFloat(rawValue, 64)
+				case excelize.CellTypeBool:
+					value = rawValue == "1"
+				}
+			}
+			element.SetMapIndex(key, reflect.ValueOf(value))
+		}
+
+		if element.Len() == 0 {
+			emptyRows++
+			continue
+		}
+		emptyRows = 0
+		slice.Set(reflect.Append(slice, element))
+	}
+	p.finish()
+	return nil
+}
+
+// WithColumnOrder sets the order of columns written from []map[string]interface{}
+// Keys not listed follow in sorted order
+func WithColumnOrder(keys ...string) Option {
+	return func(o *options) {
+		o.write.ColumnOrder = keys
+	}
+}
+
+// getMapColumns returns columns of keys of all map elements, ordered keys first
+func getMapColumns(slice reflect.Value, order []string) []column {
+	keys := map[string]bool{}
+	for i := 0; i < slice.Len(); i++ {
+		for _, key := range slice.Index(i).MapKeys() {
+			keys[key.String()] = true
+		}
+	}
+
+	var names []string
+	for _, key := range order {
+		if !keys[key] {
+			continue
+		}
+		names = append(names, key)
+		delete(keys, key)
+	}
+	rest := make([]string, 0, len(keys))
+	for key := range keys {
+		rest = append(rest, key)
+	}
+	sort.Strings(rest)
+	names = append(names, rest...)
+
+	valueType := slice.Type().Elem().Elem()
+	columns := make([]column, len(names))
+	for i, name := range names {
+		columns[i] = column{
+			field:   reflect.StructField{Name: name, Type: valueType},
+			index:   i,
+			name:    name,
+			aliases: []string{name},
+			restKey: name,
+		}
+	}
+	return columns
+}
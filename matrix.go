@@ -0,0 +1,148 @@
+package xlsx
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ReadMatrix returns typed values of the range, the counterpart of WriteMatrix
+// Numbers are float64, booleans are bool, numbers with date formats are time.Time,
+// other cells are strings and empty cells are nil
+// endCell can be empty to read up to the last used row and column of the sheet
+func ReadMatrix(file *excelize.File, sheetName string, startCell string, endCell string) ([][]interface{}, error) {
+	if startCell == "" {
+		startCell = "A1"
+	}
+	startColumnIdx, startRowIdx, err := ParseCellName(strings.ReplaceAll(startCell, "$", ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid start cell %q: %w", startCell, err)
+	}
+
+	var endColumnIdx, endRowIdx int
+	if endCell == "" {
+		rows, columns, err := FileSource{file}.Dimensions(sheetName)
+		if err != nil {
+			return nil, err
+		}
+		endColumnIdx, endRowIdx = columns-1, rows
+	} else {
+		endColumnIdx, endRowIdx, err = ParseCellName(strings.ReplaceAll(endCell, "$", ""))
+		if err != nil {
+			return nil, fmt.Errorf("invalid end cell %q: %w", endCell, err)
+		}
+	}
+	if endColumnIdx < startColumnIdx || endRowIdx < startRowIdx {
+		return nil, nil
+	}
+
+	date1904 := isDate1904(file)
+	matrix := make([][]interface{}, 0, endRowIdx-startRowIdx+1)
+	for rowIdx := startRowIdx; rowIdx <= endRowIdx; rowIdx++ {
+		row := make([]interface{}, 0, endColumnIdx-startColumnIdx+1)
+		for columnIdx := startColumnIdx; columnIdx <= endColumnIdx; columnIdx++ {
+			value, err := readTypedCell(file, sheetName, GetCellName(columnIdx, rowIdx), date1904)
+			if err != nil {
+				return nil, err
+			}
+			row = append(row, value)
+		}
+		matrix = append(matrix, row)
+	}
+	return matrix, nil
+}
+
+// readTypedCell returns the cell value converted by its type and number format
+func readTypedCell(file *excelize.File, sheetName string, cell string, date1904 bool) (interface{}, error) {
+	raw, err := file.GetCellValue(sheetName, cell, excelize.Options{RawCellValue: true})
+	if err != nil || raw == "" {
+		return nil, err
+	}
+	ctype, err := file.GetCellType(sheetName, cell)
+	if err != nil {
+		return nil, err
+	}
+
+	switch ctype {
+	case excelize.CellTypeBool:
+		return raw == "1", nil
+	case excelize.CellTypeDate:
+		return parseTime(raw, raw, excelize.CellTypeSharedString, date1904)
+	case excelize.CellTypeNumber, excelize.CellTypeUnset:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return raw, nil
+		}
+		isDate, err := isDateCell(file, sheetName, cell)
+		if err != nil {
+			return nil, err
+		}
+		if isDate {
+			return excelize.ExcelDateToTime(f, date1904)
+		}
+		return f, nil
+	}
+	return raw, nil
+}
+
+// isDateCell reports whether the number format of the cell shows dates or times
+func isDateCell(file *excelize.File, sheetName string, cell string) (bool, error) {
+	styleID, err := file.GetCellStyle(sheetName, cell)
+	if err != nil || styleID == 0 {
+		return false, err
+	}
+	// Formatting the value loads styles of the file
+	if _, err := file.GetCellValue(sheetName, cell); err != nil {
+		return false, err
+	}
+
+	styles := file.Styles
+	if styles == nil || styles.CellXfs == nil || styleID >= len(styles.CellXfs.Xf) || styles.CellXfs.Xf[styleID].NumFmtID == nil {
+		return false, nil
+	}
+	numFmtID := *styles.CellXfs.Xf[styleID].NumFmtID
+	formatCode := ""
+	if styles.NumFmts != nil {
+		for _, numFmt := range styles.NumFmts.NumFmt {
+			if numFmt.NumFmtID == numFmtID {
+				formatCode = numFmt.FormatCode
+			}
+		}
+	}
+	return IsDateFormat(numFmtID, formatCode), nil
+}
+
+// IsDateFormat reports whether the number format shows dates or times,
+// formatCode is the code of custom formats, built-in ones are known by their IDs
+func IsDateFormat(numFmtID int, formatCode string) bool {
+	switch {
+	case numFmtID >= 14 && numFmtID <= 22, numFmtID >= 45 && numFmtID <= 47:
+		return true
+	case formatCode == "":
+		return false
+	}
+
+	// Quoted text, escaped characters and [color] sections aren't date parts
+	formatCode = strings.ToLower(strings.SplitN(formatCode, ";", 2)[0])
+	inQuotes, inBrackets := false, false
+	for i := 0; i < len(formatCode); i++ {
+		ch := formatCode[i]
+		switch {
+		case ch == '"':
+			inQuotes = !inQuotes
+		case inQuotes:
+		case ch == '\\':
+			i++
+		case ch == '[':
+			inBrackets = true
+		case ch == ']':
+			inBrackets = false
+		case inBrackets:
+		case strings.IndexByte("ymdhs", ch) >= 0:
+			return true
+		}
+	}
+	return false
+}
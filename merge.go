@@ -0,0 +1,37 @@
+package xlsx
+
+import (
+	"github.com/xuri/excelize/v2"
+)
+
+// mergeVerticalColumns merges runs of consecutive data rows with equal values in columns with the mergeVertical tag,
+// e.g. the customer of its orders, empty cells aren't merged
+func mergeVerticalColumns(file *excelize.File, sheetName string, columns []column, fromRow int, toRow int) error {
+	for _, c := range columns {
+		if !getTagBool(c.field, "mergeVertical") {
+			continue
+		}
+		runStart, runValue := fromRow, ""
+		for rowIdx := fromRow; rowIdx <= toRow+1; rowIdx++ {
+			value := ""
+			if rowIdx <= toRow {
+				var err error
+				value, err = file.GetCellValue(sheetName, GetCellName(c.index, rowIdx), excelize.Options{RawCellValue: true})
+				if err != nil {
+					return err
+				}
+			}
+			if value == runValue && rowIdx <= toRow {
+				continue
+			}
+			if runValue != "" && rowIdx-1 > runStart {
+				err := file.MergeCell(sheetName, GetCellName(c.index, runStart), GetCellName(c.index, rowIdx-1))
+				if err != nil {
+					return err
+				}
+			}
+			runStart, runValue = rowIdx, value
+		}
+	}
+	return nil
+}
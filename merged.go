@@ -0,0 +1,66 @@
+package xlsx
+
+import (
+	"github.com/xuri/excelize/v2"
+)
+
+// WithMergedCells reads values of vertically merged cells in every row they cover,
+// e.g. the customer merged over its orders is read into every order
+// Only the first column of a merged range gets the value, like cells of horizontally merged ones are read
+func WithMergedCells() Option {
+	return func(o *options) {
+		o.read.MergedCells = true
+	}
+}
+
+// mergedCell is the value of the merged range filled in the column of the covered row
+type mergedCell struct {
+	columnIdx int
+	raw       string
+	formatted string
+}
+
+// loadMerged reads values of merged ranges of the sheet by covered rows below their first cells
+func (r *rowReader) loadMerged(file *excelize.File) error {
+	ranges, err := file.GetMergeCells(r.sheetName)
+	if err != nil {
+		return err
+	}
+	r.merged = map[int][]mergedCell{}
+	for _, m := range ranges {
+		columnIdx, fromRow, err := ParseCellName(m.GetStartAxis())
+		if err != nil {
+			return err
+		}
+		_, toRow, err := ParseCellName(m.GetEndAxis())
+		if err != nil {
+			return err
+		}
+		raw, err := file.GetCellValue(r.sheetName, m.GetStartAxis(), excelize.Options{RawCellValue: true})
+		if err != nil {
+			return err
+		}
+		formatted, err := file.GetCellValue(r.sheetName, m.GetStartAxis())
+		if err != nil {
+			return err
+		}
+		for rowIdx := fromRow + 1; rowIdx <= toRow; rowIdx++ {
+			r.merged[rowIdx] = append(r.merged[rowIdx], mergedCell{columnIdx: columnIdx, raw: raw, formatted: formatted})
+		}
+	}
+	return nil
+}
+
+// fillMerged sets values of merged ranges covering the current row
+func (r *rowReader) fillMerged(raw []string, formatted []string) ([]string, []string) {
+	for _, m := range r.merged[r.rowIdx] {
+		for len(raw) <= m.columnIdx {
+			raw = append(raw, "")
+		}
+		for len(formatted) <= m.columnIdx {
+			formatted = append(formatted, "")
+		}
+		raw[m.columnIdx], formatted[m.columnIdx] = m.raw, m.formatted
+	}
+	return raw, formatted
+}
@@ -0,0 +1,267 @@
+package xlsx
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Schema is the column layout of a sheet version, see Migrate
+type Schema struct {
+	Columns []SchemaColumn
+}
+
+// SchemaColumn is a column of the Schema
+type SchemaColumn struct {
+	// Name is the header
+	Name string
+	// ID identifies the column in all versions, the name by default
+	// Columns of both schemas with the same ID hold the same data, so renamed columns keep their IDs
+	ID string
+	// Default is the value of data cells of columns added by Migrate, empty if nil
+	Default interface{}
+}
+
+func (c SchemaColumn) id() string {
+	if c.ID != "" {
+		return c.ID
+	}
+	return c.Name
+}
+
+// Migrate changes columns of the sheet with the from schema to the to schema:
+// columns are moved to their new places and renamed, columns only in to are added with defaults
+// and columns only in from are dropped
+// The header is the first of top rows with columns of from in their order starting from A,
+// otherwise SchemaError is returned, columns after them follow the migrated columns
+// Rows above the header like the banner of WithBanner stay in place, ranges merged across all columns of from
+// are merged across all columns of to
+// Moved cells keep their values, formulas and styles, column widths move with them,
+// references in formulas aren't changed
+func Migrate(file *excelize.File, sheetName string, from Schema, to Schema) error {
+	if index, err := file.GetSheetIndex(sheetName); err != nil || index < 0 {
+		return fmt.Errorf("sheet %q not found", sheetName)
+	}
+	headerRow, err := checkMigration(file, sheetName, from)
+	if err != nil {
+		return err
+	}
+
+	rows, err := file.GetRows(sheetName)
+	if err != nil {
+		return err
+	}
+
+	// Cells of kept columns are saved before columns are replaced
+	fromColumns := map[string]int{}
+	for i, c := range from.Columns {
+		fromColumns[c.id()] = i
+	}
+	saved := map[int][]cellCopy{}
+	widths := map[int]float64{}
+	for _, c := range to.Columns {
+		i, ok := fromColumns[c.id()]
+		if !ok {
+			continue
+		}
+		if saved[i], err = copyColumn(file, sheetName, i, headerRow, len(rows)); err != nil {
+			return err
+		}
+		if widths[i], err = file.GetColWidth(sheetName, getColumnLetter(i)); err != nil {
+			return err
+		}
+	}
+	above, merged, err := saveAboveHeader(file, sheetName, headerRow, len(from.Columns))
+	if err != nil {
+		return err
+	}
+
+	for range from.Columns {
+		if err := file.RemoveCol(sheetName, "A"); err != nil {
+			return err
+		}
+	}
+	if len(to.Columns) > 0 {
+		if err := file.InsertCols(sheetName, "A", len(to.Columns)); err != nil {
+			return err
+		}
+	}
+
+	for columnIdx, c := range to.Columns {
+		letter := getColumnLetter(columnIdx)
+		i, ok := fromColumns[c.id()]
+		if !ok {
+			if err := file.SetCellStr(sheetName, GetCellName(columnIdx, headerRow), c.Name); err != nil {
+				return err
+			}
+			if c.Default == nil {
+				continue
+			}
+			for rowIdx := headerRow + 1; rowIdx <= len(rows); rowIdx++ {
+				if err := setCellValue(file, sheetName, GetCellName(columnIdx, rowIdx), c.Default); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		for rowi, cell := range saved[i] {
+			if err := cell.write(file, sheetName, GetCellName(columnIdx, headerRow+rowi)); err != nil {
+				return err
+			}
+		}
+		if err := file.SetCellStr(sheetName, GetCellName(columnIdx, headerRow), c.Name); err != nil {
+			return err
+		}
+		if err := file.SetColWidth(sheetName, letter, letter, widths[i]); err != nil {
+			return err
+		}
+	}
+	return restoreAboveHeader(file, sheetName, above, merged, len(from.Columns), len(to.Columns))
+}
+
+// checkMigration returns the header row, the first of top rows starting with columns of the schema,
+// or SchemaError with problems of the first row if there is none
+func checkMigration(file *excelize.File, sheetName string, from Schema) (int, error) {
+	var problems []string
+	for rowIdx := 1; rowIdx <= headerSearchRows; rowIdx++ {
+		var rowProblems []string
+		for columnIdx, c := range from.Columns {
+			header, err := file.GetCellValue(sheetName, GetCellName(columnIdx, rowIdx))
+			if err != nil {
+				return 0, err
+			}
+			if header = strings.TrimSpace(header); header != c.Name {
+				rowProblems = append(rowProblems, fmt.Sprintf("column %s: header %q, expected %q", getColumnLetter(columnIdx), header, c.Name))
+			}
+		}
+		if len(rowProblems) == 0 {
+			return rowIdx, nil
+		}
+		if rowIdx == 1 {
+			problems = rowProblems
+		}
+	}
+	return 0, &SchemaError{SheetName: sheetName, Problems: problems}
+}
+
+// saveAboveHeader returns cells of the columns in rows above the header and unmerges ranges within them
+func saveAboveHeader(file *excelize.File, sheetName string, headerRow int, columns int) (map[int][]cellCopy, []CellRange, error) {
+	above := map[int][]cellCopy{}
+	if headerRow == 1 {
+		return above, nil, nil
+	}
+	for columnIdx := 0; columnIdx < columns; columnIdx++ {
+		cells, err := copyColumn(file, sheetName, columnIdx, 1, headerRow-1)
+		if err != nil {
+			return nil, nil, err
+		}
+		above[columnIdx] = cells
+	}
+
+	ranges, err := file.GetMergeCells(sheetName)
+	if err != nil {
+		return nil, nil, err
+	}
+	var merged []CellRange
+	for _, m := range ranges {
+		fromColumn, fromRow, err := ParseCellName(m.GetStartAxis())
+		if err != nil {
+			return nil, nil, err
+		}
+		toColumn, toRow, err := ParseCellName(m.GetEndAxis())
+		if err != nil {
+			return nil, nil, err
+		}
+		if toRow >= headerRow || toColumn >= columns {
+			continue
+		}
+		if err := file.UnmergeCell(sheetName, m.GetStartAxis(), m.GetEndAxis()); err != nil {
+			return nil, nil, err
+		}
+		merged = append(merged, CellRange{FromColumn: fromColumn, FromRow: fromRow, ToColumn: toColumn, ToRow: toRow})
+	}
+	return above, merged, nil
+}
+
+// restoreAboveHeader writes cells saved by saveAboveHeader back to their places within the migrated columns
+// and merges their ranges again, ranges across all old columns span all new ones
+func restoreAboveHeader(file *excelize.File, sheetName string, above map[int][]cellCopy, merged []CellRange, fromColumns int, toColumns int) error {
+	for columnIdx, cells := range above {
+		if columnIdx >= toColumns {
+			continue
+		}
+		for rowi, cell := range cells {
+			if err := cell.write(file, sheetName, GetCellName(columnIdx, rowi+1)); err != nil {
+				return err
+			}
+		}
+	}
+	for _, r := range merged {
+		if r.ToColumn == fromColumns-1 || r.ToColumn >= toColumns {
+			r.ToColumn = toColumns - 1
+		}
+		if r.FromColumn >= toColumns || (r.FromColumn == r.ToColumn && r.FromRow == r.ToRow) {
+			continue
+		}
+		if err := file.MergeCell(sheetName, GetCellName(r.FromColumn, r.FromRow), GetCellName(r.ToColumn, r.ToRow)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cellCopy is the cell content moved by Migrate
+type cellCopy struct {
+	raw     string
+	formula string
+	ctype   excelize.CellType
+	style   int
+}
+
+// copyColumn returns cells of the column in rows from fromRow to toRow
+func copyColumn(file *excelize.File, sheetName string, columnIdx int, fromRow int, toRow int) ([]cellCopy, error) {
+	if toRow < fromRow {
+		return nil, nil
+	}
+	cells := make([]cellCopy, toRow-fromRow+1)
+	for rowi := range cells {
+		cell := GetCellName(columnIdx, fromRow+rowi)
+		c := &cells[rowi]
+		var err error
+		if c.raw, err = file.GetCellValue(sheetName, cell, excelize.Options{RawCellValue: true}); err != nil {
+			return nil, err
+		}
+		if c.formula, err = file.GetCellFormula(sheetName, cell); err != nil {
+			return nil, err
+		}
+		if c.ctype, err = file.GetCellType(sheetName, cell); err != nil {
+			return nil, err
+		}
+		if c.style, err = file.GetCellStyle(sheetName, cell); err != nil {
+			return nil, err
+		}
+	}
+	return cells, nil
+}
+
+// write sets the copied cell, text stays text and numbers stay numbers
+func (c cellCopy) write(file *excelize.File, sheetName string, cell string) error {
+	var err error
+	switch {
+	case c.formula != "":
+		err = file.SetCellFormula(sheetName, cell, c.formula)
+	case c.raw == "":
+	case c.ctype == excelize.CellTypeBool:
+		err = file.SetCellBool(sheetName, cell, c.raw == "1")
+	case c.ctype == excelize.CellTypeSharedString, c.ctype == excelize.CellTypeInlineString:
+		err = file.SetCellStr(sheetName, cell, c.raw)
+	default:
+		err = file.SetCellDefault(sheetName, cell, c.raw)
+	}
+	if err != nil || c.style == 0 {
+		return err
+	}
+	return file.SetCellStyle(sheetName, cell, cell, c.style)
+}
@@ -0,0 +1,48 @@
+package xlsx
+
+import (
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestMigrateBannerSheet(t *testing.T) {
+	type product struct {
+		SKU   string
+		Price float64
+	}
+	file := excelize.NewFile()
+	if err := Write(file, "Products", []product{{"a", 1}, {"b", 2}}, WithBanner("Prices")); err != nil {
+		t.Fatal(err)
+	}
+
+	from := Schema{Columns: []SchemaColumn{{Name: "SKU"}, {Name: "Price"}}}
+	to := Schema{Columns: []SchemaColumn{{Name: "Cost", ID: "Price"}, {Name: "Currency", Default: "USD"}, {Name: "SKU"}}}
+	if err := Migrate(file, "Products", from, to); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := file.GetRows("Products")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{{"Prices"}, {"Cost", "Currency", "SKU"}, {"1", "USD", "a"}, {"2", "USD", "b"}}
+	if len(rows) != len(want) {
+		t.Fatalf("got %v, want %v", rows, want)
+	}
+	for i := range want {
+		for j := range want[i] {
+			if rows[i][j] != want[i][j] {
+				t.Fatalf("got %v, want %v", rows, want)
+			}
+		}
+	}
+
+	merged, err := file.GetMergeCells("Products")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(merged) != 1 || merged[0].GetStartAxis() != "A1" || merged[0].GetEndAxis() != "C1" {
+		t.Fatalf("got merged cells %v, want the banner A1:C1", merged)
+	}
+}
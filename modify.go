@@ -0,0 +1,128 @@
+package xlsx
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ModifySheet reads the sheet into v like UnmarshalSheet, calls fn to change elements and writes changed fields
+// back to their cells, v must be a pointer to a slice of structs
+// The sheet isn't recreated, so comments, widths, conditional formats and other cells stay,
+// cells of unchanged fields keep their formulas too
+// fn must not add or remove elements, Write a new sheet for that
+func ModifySheet(file *excelize.File, sheetName string, v interface{}, fn func() error, opts ...Option) error {
+	o := newOptions(opts).read.withDefaults()
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("pointer to slice is required")
+	}
+	slice := rv.Elem()
+	elemType := slice.Type().Elem()
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return fmt.Errorf("slice of structs only is allowed")
+	}
+	if index, err := file.GetSheetIndex(sheetName); err != nil || index < 0 {
+		return fmt.Errorf("sheet %q not found", sheetName)
+	}
+
+	columns, err := sheetColumns(file, sheetName, elemType, o)
+	if err != nil {
+		return err
+	}
+
+	// Elements are written back to their source rows
+	p := &Provenance{}
+	o.Provenance, o.flat = p, true
+	slice.Set(slice.Slice(0, 0))
+	if err := unmarshalSheetTyped(file, sheetName, slice, o); err != nil {
+		return err
+	}
+
+	before := make([][]string, slice.Len())
+	for i := range before {
+		if before[i], err = elementCells(reflect.Indirect(slice.Index(i)), columns); err != nil {
+			return err
+		}
+	}
+
+	if err := fn(); err != nil {
+		return err
+	}
+	if slice.Len() != len(p.Rows) {
+		return fmt.Errorf("sheet %s: %d elements were read, but %d are written back", sheetName, len(p.Rows), slice.Len())
+	}
+
+	for i, rowIdx := range p.Rows {
+		element := reflect.Indirect(slice.Index(i))
+		after, err := elementCells(element, columns)
+		if err != nil {
+			return err
+		}
+		for j, c := range columns {
+			if after[j] == before[i][j] {
+				continue
+			}
+			value, err := c.cellValue(element)
+			if err != nil {
+				return fmt.Errorf("field %s: %w", c.field.Name, err)
+			}
+			if err := setCellValue(file, sheetName, GetCellName(c.index, rowIdx), value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// sheetColumns returns struct fields found in the header of the sheet with indexes of their sheet columns
+func sheetColumns(file *excelize.File, sheetName string, elemType reflect.Type, o UnmarshalOptions) ([]column, error) {
+	if o.NoHeader {
+		return mapColumns(elemType, nil, o.TemplateVersion), nil
+	}
+	h, err := readHeader(file, sheetName, o)
+	if err != nil || h == nil {
+		return nil, err
+	}
+	return mapColumns(elemType, h, o.TemplateVersion), nil
+}
+
+// readHeader parses the header row of the sheet, nil if the sheet has no such row
+func readHeader(file *excelize.File, sheetName string, o UnmarshalOptions) (*header, error) {
+	rows, err := newRowReader(file, sheetName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	rows.clean = o.cellCleaner()
+
+	for rows.Next() {
+		if rows.rowIdx < o.HeaderRow {
+			continue
+		}
+		_, formatted, err := rows.Row()
+		if err != nil {
+			return nil, err
+		}
+		return parseHeader(formatted, o), nil
+	}
+	return nil, nil
+}
+
+// elementCells returns cell values of the element as text to compare them
+func elementCells(element reflect.Value, columns []column) ([]string, error) {
+	cells := make([]string, len(columns))
+	for i, c := range columns {
+		value, err := c.cellValue(element)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", c.field.Name, err)
+		}
+		cells[i] = fmt.Sprint(value)
+	}
+	return cells, nil
+}
@@ -0,0 +1,72 @@
+package xlsx
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// UnmarshalMulti reads the sheet into several slices of structs in one pass, e.g. a wide sheet feeding
+// customers and their orders, dst are pointers to slices like []interface{}{&customers, &orders}
+// Every row is read into every type like Decoder does, rows with empty cells of a type aren't appended to its slice
+// Cells which can't be converted are collected for all types with CollectErrors
+func UnmarshalMulti(file *excelize.File, sheetName string, dst []interface{}, opts ...Option) error {
+	slices := make([]reflect.Value, len(dst))
+	for i, v := range dst {
+		rv := reflect.ValueOf(v)
+		if err := checkDestination(rv); err != nil {
+			return err
+		}
+		elemType := rv.Elem().Type().Elem()
+		if elemType.Kind() == reflect.Ptr {
+			elemType = elemType.Elem()
+		}
+		if elemType.Kind() != reflect.Struct {
+			return fmt.Errorf("pointer to slice of structs is required")
+		}
+		slices[i] = rv.Elem()
+	}
+
+	dec, err := NewDecoder(file, sheetName, opts...)
+	if err != nil {
+		return err
+	}
+	defer dec.Close()
+
+	var cellErrors CellErrors
+	for dec.Next() {
+		for _, slice := range slices {
+			elemType := slice.Type().Elem()
+			isPtr := elemType.Kind() == reflect.Ptr
+			if isPtr {
+				elemType = elemType.Elem()
+			}
+
+			element := reflect.New(elemType)
+			empty, err := dec.scan(element.Elem())
+			var rowErrors CellErrors
+			if errors.As(err, &rowErrors) {
+				cellErrors = append(cellErrors, rowErrors...)
+			} else if err != nil {
+				return err
+			}
+			if empty {
+				continue
+			}
+			if isPtr {
+				slice.Set(reflect.Append(slice, element))
+			} else {
+				slice.Set(reflect.Append(slice, element.Elem()))
+			}
+		}
+	}
+	if err := dec.Err(); err != nil {
+		return err
+	}
+	if len(cellErrors) > 0 {
+		return cellErrors
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package xlsx
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// FieldNames holds defined names created for columns of a written sheet
+type FieldNames struct {
+	sheetName string
+	names     map[string]string
+	ranges    map[string]CellRange
+}
+
+// DefineNames creates a workbook defined name for the data range of every column
+// written by Write, e.g. "Users_Email" refers to 'Users'!$C$2:$C$11
+// Options are the ones passed to Write, banners, type rows and totals move data rows down
+// Ranges end at the last data row of the sheet, group summaries and rows spread by SheetRouter are counted as written
+// Existing names with the same name are replaced
+func DefineNames(file *excelize.File, sheetName string, data interface{}, opts ...Option) (*FieldNames, error) {
+	if _, err := RangeOf(data, opts...); err != nil {
+		return nil, err
+	}
+	elemType := reflect.TypeOf(data).Elem()
+	headerRow, _, _, dataRow := newOptions(opts).write.sheetRows(getColumns(elemType))
+	lastRow, err := lastDataRow(file, sheetName, headerRow, dataRow)
+	if err != nil {
+		return nil, err
+	}
+	if lastRow < dataRow {
+		lastRow = dataRow
+	}
+
+	names := &FieldNames{
+		sheetName: sheetName,
+		names:     map[string]string{},
+		ranges:    map[string]CellRange{},
+	}
+
+	for _, c := range getColumns(elemType) {
+		name := definedName(sheetName, c.field.Name)
+		columnRange := CellRange{FromColumn: c.index, FromRow: dataRow, ToColumn: c.index, ToRow: lastRow}
+
+		file.DeleteDefinedName(&excelize.DefinedName{Name: name})
+		err := file.SetDefinedName(&excelize.DefinedName{
+			Name:     name,
+			RefersTo: absoluteRef(sheetName, columnRange),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("define name %s: %w", name, err)
+		}
+
+		names.names[c.field.Name] = name
+		names.ranges[c.field.Name] = columnRange
+	}
+	return names, nil
+}
+
+// NameFor returns the defined name of the field column or empty string if the field is not written
+func (n *FieldNames) NameFor(field string) string {
+	return n.names[field]
+}
+
+// RangeFor returns the data range of the field column
+func (n *FieldNames) RangeFor(field string) (CellRange, bool) {
+	r, ok := n.ranges[field]
+	return r, ok
+}
+
+// RefFor returns the absolute reference of the field column, e.g. 'Users'!$C$2:$C$11
+func (n *FieldNames) RefFor(field string) string {
+	r, ok := n.ranges[field]
+	if !ok {
+		return ""
+	}
+	return absoluteRef(n.sheetName, r)
+}
+
+// ResolveName returns the sheet and the range a defined name refers to
+func ResolveName(file *excelize.File, name string) (string, CellRange, error) {
+	for _, dn := range file.GetDefinedName() {
+		if dn.Name != name {
+			continue
+		}
+
+		ref := strings.TrimPrefix(dn.RefersTo, "=")
+		i := strings.LastIndex(ref, "!")
+		if i < 0 {
+			return "", CellRange{}, fmt.Errorf("defined name %s refers to %q without sheet", name, dn.RefersTo)
+		}
+
+		sheetName := strings.Trim(ref[:i], "'")
+		sheetName = strings.ReplaceAll(sheetName, "''", "'")
+		r, err := ParseCellRange(ref[i+1:])
+		if err != nil {
+			return "", CellRange{}, err
+		}
+		return sheetName, r, nil
+	}
+	return "", CellRange{}, fmt.Errorf("defined name %s not found", name)
+}
+
+// absoluteRef returns reference like 'Sheet'!$A$2:$A$10
+func absoluteRef(sheetName string, r CellRange) string {
+	from, _ := excelize.CoordinatesToCellName(r.FromColumn+1, r.FromRow, true)
+	to, _ := excelize.CoordinatesToCellName(r.ToColumn+1, r.ToRow, true)
+	return quoteSheetName(sheetName) + "!" + from + ":" + to
+}
+
+func quoteSheetName(sheetName string) string {
+	return "'" + strings.ReplaceAll(sheetName, "'", "''") + "'"
+}
+
+// definedName builds a valid defined name from the sheet and field names
+func definedName(sheetName string, field string) string {
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.' {
+			return r
+		}
+		return '_'
+	}, sheetName+"_"+field)
+
+	if r := []rune(name)[0]; !unicode.IsLetter(r) && r != '_' {
+		name = "_" + name
+	}
+	return name
+}
+
+// lastDataRow returns the last row of the data written from dataRow, group summaries included,
+// the data ends at the footer or at the gap of empty rows like Unmarshal reads it
+func lastDataRow(file *excelize.File, sheetName string, headerRow int, dataRow int) (int, error) {
+	rows, err := newRowReader(file, sheetName)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	o := UnmarshalOptions{}.withDefaults()
+	var h *header
+	last := dataRow - 1
+	emptyRows := 0
+	for rows.Next() && emptyRows < o.EmptyRowGap {
+		if rows.rowIdx != headerRow && rows.rowIdx < dataRow {
+			continue
+		}
+		raw, formatted, err := rows.Row()
+		if err != nil {
+			return 0, err
+		}
+		if rows.rowIdx == headerRow {
+			h = parseHeader(formatted, o)
+			continue
+		}
+		if h.rowKind(raw) == footerRowKind {
+			break
+		}
+		if isEmptyRow(raw) {
+			emptyRows++
+			continue
+		}
+		emptyRows, last = 0, rows.rowIdx
+	}
+	return last, nil
+}
@@ -0,0 +1,74 @@
+package xlsx
+
+import (
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestDefineNamesBannerTotals(t *testing.T) {
+	type row struct {
+		Name string
+		Qty  int
+	}
+	data := []row{{"a", 1}, {"b", 2}}
+	opts := []Option{WithBanner("Report"), WithTotals(map[string]string{"Qty": "sum"})}
+
+	file := excelize.NewFile()
+	if err := Write(file, "Data", data, opts...); err != nil {
+		t.Fatal(err)
+	}
+	names, err := DefineNames(file, "Data", data, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Banner is row 1, header row 2, totals row 3, data rows 4-5
+	got, ok := names.RangeFor("Qty")
+	want := CellRange{FromColumn: 1, FromRow: 4, ToColumn: 1, ToRow: 5}
+	if !ok || got != want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+	for _, r := range []int{got.FromRow, got.ToRow} {
+		value, err := file.GetCellValue("Data", GetCellName(got.FromColumn, r))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if value == "" || value == "Qty" {
+			t.Fatalf("row %d of the range is %q, not data", r, value)
+		}
+	}
+}
+
+func TestDefineNamesWrittenRows(t *testing.T) {
+	type row struct {
+		Region string
+		Qty    int
+	}
+	data := []row{{"a", 1}, {"b", 2}, {"a", 3}}
+
+	file := excelize.NewFile()
+	router := WithSheetRouter(func(v interface{}) string { return v.(row).Region })
+	if err := Write(file, "Data", data, router, WithFooterRows([]string{"foot"})); err != nil {
+		t.Fatal(err)
+	}
+	names, err := DefineNames(file, "b", data, router)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := names.RangeFor("Qty"); got.FromRow != 2 || got.ToRow != 2 {
+		t.Fatalf("routed sheet range %+v, want row 2", got)
+	}
+
+	grouping := WithGrouping(Grouping{GroupBy: "Region"})
+	if err := Write(file, "Grouped", data, grouping); err != nil {
+		t.Fatal(err)
+	}
+	if names, err = DefineNames(file, "Grouped", data, grouping); err != nil {
+		t.Fatal(err)
+	}
+	// Three groups of one row with their summaries
+	if got, _ := names.RangeFor("Qty"); got.FromRow != 2 || got.ToRow != 7 {
+		t.Fatalf("grouped sheet range %+v, want rows 2-7", got)
+	}
+}
@@ -0,0 +1,83 @@
+package xlsx
+
+import (
+	"reflect"
+)
+
+// childrenField returns the slice field with the children tag and the struct type of its elements
+// Detail rows of master-detail sheets follow their parent row with parent columns blank, e.g.
+//
+//	type Order struct {
+//		ID    int64  `xlsx:"key"`
+//		Lines []Line `xlsx:"children"`
+//	}
+//
+// A row starts a new parent if any parent column is filled, or if the key tag is used,
+// if its key columns are filled and differ from the current parent
+func childrenField(t reflect.Type) (reflect.StructField, reflect.Type, bool) {
+	if t.Kind() != reflect.Struct {
+		return reflect.StructField{}, nil, false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !getTagBool(field, "children") || field.Type.Kind() != reflect.Slice {
+			continue
+		}
+
+		childType := field.Type.Elem()
+		if childType.Kind() == reflect.Ptr {
+			childType = childType.Elem()
+		}
+		if childType.Kind() == reflect.Struct {
+			return field, childType, true
+		}
+	}
+	return reflect.StructField{}, nil, false
+}
+
+// keyColumns returns columns with the key tag
+func keyColumns(columns []column) []column {
+	var keys []column
+	for _, c := range columns {
+		if getTagBool(c.field, "key") {
+			keys = append(keys, c)
+		}
+	}
+	return keys
+}
+
+// rowKey returns raw values of key columns, nil if all of them are empty
+func rowKey(keys []column, raw []string) []string {
+	key := make([]string, len(keys))
+	empty := true
+	for i, c := range keys {
+		key[i] = cellAt(raw, c.index)
+		if key[i] != "" {
+			empty = false
+		}
+	}
+	if empty {
+		return nil
+	}
+	return key
+}
+
+func equalKeys(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// appendChild appends the child struct to the slice field, the slice may hold pointers
+func appendChild(field reflect.Value, child reflect.Value) {
+	if field.Type().Elem().Kind() == reflect.Ptr {
+		child = child.Addr()
+	}
+	field.Set(reflect.Append(field, child))
+}
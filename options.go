@@ -0,0 +1,199 @@
+package xlsx
+
+import "github.com/xuri/excelize/v2"
+
+// WriteOptions configures Write
+type WriteOptions struct {
+	// OnLayout is called after the sheet is written
+	OnLayout func(Layout)
+	// OnField is called before every cell is written
+	OnField func(ctx *WriteContext) error
+	// OnRow is called after every data row is written
+	OnRow func(ctx *WriteContext) error
+	// SheetRouter returns the sheet name for the slice element,
+	// empty name means the sheet passed to Write
+	SheetRouter func(v interface{}) string
+	// IndexSheet is the name of the sheet listing all other sheets, no index if empty
+	IndexSheet string
+	// IndexBackLink is the text of the link to the index added to every written sheet, no link if empty
+	IndexBackLink string
+	// Banner is the text of the styled row above the header, no banner if empty
+	Banner string
+	// FooterRows are lines written below the data after an empty row
+	FooterRows []string
+	// Grouping puts rows into outline groups with summary rows, no groups if GroupBy is empty
+	Grouping Grouping
+	// Totals maps field names to aggregations of the frozen row below the header, no row if nil, see WithTotals
+	Totals map[string]string
+	// SheetPassword protects sheets with locked columns, they are protected without a password if empty
+	SheetPassword string
+	// TypeRow adds a hidden row with type hints of columns below the header, see WithTypeRow
+	TypeRow bool
+	// Schema saves the column schema of written sheets, see WithSchema
+	Schema bool
+	// ColumnOrder are keys of map elements in order of columns, see WithColumnOrder
+	ColumnOrder []string
+	// Provenance adds hidden columns with source rows and the batch ID, see WithProvenance
+	Provenance *Provenance
+	// OnProgress is called after every element is written, see WithProgress
+	OnProgress func(rowsDone, rowsTotal int)
+	// Date1904 switches the workbook to the 1904 date system, see WithDate1904
+	Date1904 bool
+	// HeaderStyle is the style of header cells over the body style, see WithHeaderStyle
+	HeaderStyle Style
+	// BodyStyle is the style of data cells, see WithBodyStyle
+	BodyStyle Style
+	// NamedStyles are styles of fields with the style tag, see WithNamedStyles
+	NamedStyles map[string]Style
+	// SheetOrder are names of written sheets in the order of their tabs, see WithSheetOrder
+	SheetOrder []string
+	// ActiveSheet is the sheet shown when the file is opened, unchanged if empty
+	ActiveSheet string
+	// FullCalcOnLoad makes Excel recalculate formulas when the file is opened, see WithFullCalcOnLoad
+	FullCalcOnLoad bool
+	// FreezeHeader keeps the header visible while scrolling, see WithFreezeHeader
+	FreezeHeader bool
+	// AutoFilter adds filter buttons to the header, see WithAutoFilter
+	AutoFilter bool
+	// Precalc stores results of formulas of written sheets, see WithPrecalc
+	Precalc bool
+	// ForcedText writes string fields as text cells, see WithForcedText
+	ForcedText bool
+	// ConditionalFormats are conditional formats of fields with the condfmt tag by name, see WithConditionalFormats
+	ConditionalFormats map[string][]excelize.ConditionalFormatOptions
+	// Transforms convert written values of fields by field name, see WithTransforms
+	Transforms map[string]func(string) (interface{}, error)
+	// CopyRowStyles copies styles of the first data row of templates downward, see WithCopyRowStyles
+	CopyRowStyles bool
+}
+
+// headerRow returns the row of column names, rows above it are taken by the banner
+func (o WriteOptions) headerRow() int {
+	row := 1
+	if o.Banner != "" {
+		row++
+	}
+	return row
+}
+
+// sheetRows returns rows of the sheet written with the options, typeRow and totalsRow are 0 without them
+// The type row and the totals go between the header and the data
+func (o WriteOptions) sheetRows(columns []column) (headerRow int, typeRow int, totalsRow int, dataRow int) {
+	headerRow = o.headerRow()
+	dataRow = headerRow + 1
+	if o.TypeRow {
+		typeRow = dataRow
+		dataRow++
+	}
+	if hasTotals(columns, o) {
+		totalsRow = dataRow
+		dataRow++
+	}
+	return headerRow, typeRow, totalsRow, dataRow
+}
+
+// Option customizes Write and Unmarshal
+type Option func(*options)
+
+type options struct {
+	write WriteOptions
+	read  UnmarshalOptions
+
+	// progress counts written elements of the slice passed to Write
+	progress *progress
+	// dataRows are numbers of data rows of sheets written by the call, see writeIndexSheet
+	dataRows map[string]int
+	// sourceIndexes are indexes of elements of the written sheet in the slice passed to Write,
+	// nil if they are the same, see writeRouted
+	sourceIndexes []int
+	// created are sheets of WithSheetOrder created before anything is written, see createSheets
+	created map[string]bool
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithWriteOptions replaces all write options
+func WithWriteOptions(writeOptions WriteOptions) Option {
+	return func(o *options) {
+		o.write = writeOptions
+	}
+}
+
+// WithLayout sets callback receiving the layout of the written sheet
+func WithLayout(fn func(Layout)) Option {
+	return func(o *options) {
+		o.write.OnLayout = fn
+	}
+}
+
+// WithUnmarshalOptions replaces all unmarshal options
+func WithUnmarshalOptions(unmarshalOptions UnmarshalOptions) Option {
+	return func(o *options) {
+		o.read = unmarshalOptions
+	}
+}
+
+// WithSheetRouter spreads the slice across sheets, fn returns the sheet name for every element
+// Every sheet gets its own header
+func WithSheetRouter(fn func(v interface{}) string) Option {
+	return func(o *options) {
+		o.write.SheetRouter = fn
+	}
+}
+
+// WithIndexSheet adds the first sheet with links to all other sheets and their row counts
+// The index is rebuilt on every Write
+func WithIndexSheet(sheetName string) Option {
+	return func(o *options) {
+		o.write.IndexSheet = sheetName
+	}
+}
+
+// WithIndexBackLink adds a link to the index sheet next to the header of every written sheet
+// The default text is "Back to index", it works together with WithIndexSheet
+func WithIndexBackLink(text string) Option {
+	return func(o *options) {
+		if text == "" {
+			text = "Back to index"
+		}
+		o.write.IndexBackLink = text
+	}
+}
+
+// WithDate1904 makes the workbook use the 1904 date system expected by old Mac consumers
+// Dates of cells written after the switch are correct, it should be used for new workbooks
+// as serial numbers written before keep their values and move by 4 years
+func WithDate1904() Option {
+	return func(o *options) {
+		o.write.Date1904 = true
+	}
+}
+
+// WithFullCalcOnLoad makes Excel recalculate all formulas when the file is opened,
+// formulas are written without cached results, so readers see values only after a recalculation
+func WithFullCalcOnLoad() Option {
+	return func(o *options) {
+		o.write.FullCalcOnLoad = true
+	}
+}
+
+// WithBanner adds a styled row like "CONFIDENTIAL" above the header, data moves one row down
+func WithBanner(text string) Option {
+	return func(o *options) {
+		o.write.Banner = text
+	}
+}
+
+// WithFooterRows adds lines like disclaimers below the data, every line is merged across all columns
+// Footer rows are marked in a hidden column, Unmarshal stops at them
+func WithFooterRows(lines []string) Option {
+	return func(o *options) {
+		o.write.FooterRows = lines
+	}
+}
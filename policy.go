@@ -0,0 +1,126 @@
+package xlsx
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Policy describes which sheets and columns a recipient gets
+// Columns are referenced by their header names
+type Policy struct {
+	HideSheets  []string
+	DropSheets  []string
+	HideColumns map[string][]string
+	DropColumns map[string][]string
+	// HeaderRow is the row with column names, 1 by default
+	HeaderRow int
+}
+
+// Variants builds a copy of the file for every recipient of the policy map
+func Variants(file *excelize.File, policies map[string]Policy) (map[string]*excelize.File, error) {
+	variants := map[string]*excelize.File{}
+	for recipient, policy := range policies {
+		variant, err := Variant(file, policy)
+		if err != nil {
+			return nil, fmt.Errorf("recipient %s: %w", recipient, err)
+		}
+		variants[recipient] = variant
+	}
+	return variants, nil
+}
+
+// Variant returns a copy of the file with sheets and columns hidden or dropped by the policy
+// The source file is not changed
+func Variant(file *excelize.File, policy Policy) (*excelize.File, error) {
+	buf, err := file.WriteToBuffer()
+	if err != nil {
+		return nil, err
+	}
+	variant, err := excelize.OpenReader(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	headerRow := policy.HeaderRow
+	if headerRow < 1 {
+		headerRow = 1
+	}
+
+	for sheetName, names := range policy.DropColumns {
+		columns, err := findColumns(variant, sheetName, headerRow, names)
+		if err != nil {
+			return nil, err
+		}
+		// Remove from the right so indexes of remaining columns don't move
+		sort.Sort(sort.Reverse(sort.IntSlice(columns)))
+		for _, columnIdx := range columns {
+			if err := variant.RemoveCol(sheetName, getColumnLetter(columnIdx)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for sheetName, names := range policy.HideColumns {
+		columns, err := findColumns(variant, sheetName, headerRow, names)
+		if err != nil {
+			return nil, err
+		}
+		for _, columnIdx := range columns {
+			if err := variant.SetColVisible(sheetName, getColumnLetter(columnIdx), false); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, sheetName := range policy.DropSheets {
+		if err := variant.DeleteSheet(sheetName); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, sheetName := range policy.HideSheets {
+		if err := variant.SetSheetVisible(sheetName, false); err != nil {
+			return nil, err
+		}
+	}
+	return variant, nil
+}
+
+// findColumns returns indexes of columns with the given header names
+func findColumns(file *excelize.File, sheetName string, headerRow int, names []string) ([]int, error) {
+	rows, err := file.Rows(sheetName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var header []string
+	for rowIdx := 1; rows.Next(); rowIdx++ {
+		if rowIdx == headerRow {
+			header, err = rows.Columns()
+			if err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+
+	var columns []int
+	for _, name := range names {
+		found := false
+		for columnIdx, h := range header {
+			if strings.TrimSpace(h) == name {
+				columns = append(columns, columnIdx)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("column %q not found in sheet %s", name, sheetName)
+		}
+	}
+	return columns, nil
+}
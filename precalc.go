@@ -0,0 +1,155 @@
+package xlsx
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// WithPrecalc calculates formulas of written sheets and stores results in cells,
+// so readers which don't calculate formulas see values
+// SUBTOTAL aggregations of totals and group summaries are calculated from written values,
+// nested SUBTOTAL cells like group summaries under the totals are skipped like Excel does
+// Only numeric results are stored, formulas excelize can't calculate are left without results
+func WithPrecalc() Option {
+	return func(o *options) {
+		o.write.Precalc = true
+	}
+}
+
+// subtotalFormula matches SUBTOTAL aggregations written by Write like "SUBTOTAL(9,B3:B10)"
+var subtotalFormula = regexp.MustCompile(`^=?SUBTOTAL\((\d+),\s*([A-Z]+[0-9]+):([A-Z]+[0-9]+)\)$`)
+
+// precalcSheet stores results of formulas of the sheet in cells up to the last column and row
+// excelize setters remove formulas of cells, so every formula is set again after its result
+func precalcSheet(file *excelize.File, sheetName string, lastColumn int, lastRow int) error {
+	formulas := map[string]string{}
+	var subtotals []string
+	for rowIdx := 1; rowIdx <= lastRow; rowIdx++ {
+		for columnIdx := 0; columnIdx <= lastColumn; columnIdx++ {
+			cell := GetCellName(columnIdx, rowIdx)
+			formula, err := file.GetCellFormula(sheetName, cell)
+			if err != nil {
+				return err
+			}
+			if formula == "" {
+				continue
+			}
+			formulas[cell] = formula
+			if subtotalFormula.MatchString(formula) {
+				subtotals = append(subtotals, cell)
+			}
+		}
+	}
+
+	// Other formulas go first, so aggregations see their results
+	for cell, formula := range formulas {
+		if subtotalFormula.MatchString(formula) {
+			continue
+		}
+		result, err := file.CalcCellValue(sheetName, cell)
+		if err != nil {
+			continue
+		}
+		if err := setFormulaResult(file, sheetName, cell, formula, result); err != nil {
+			return err
+		}
+	}
+	for _, cell := range subtotals {
+		result, ok, err := calcSubtotal(file, sheetName, formulas[cell], formulas)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if err := setFormulaResult(file, sheetName, cell, formulas[cell], result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setFormulaResult stores the numeric result of the formula of the cell, other results are skipped
+func setFormulaResult(file *excelize.File, sheetName string, cell string, formula string, result string) error {
+	if _, err := strconv.ParseFloat(result, 64); err != nil {
+		return nil
+	}
+	if err := file.SetCellDefault(sheetName, cell, result); err != nil {
+		return err
+	}
+	return file.SetCellFormula(sheetName, cell, formula)
+}
+
+// calcSubtotal returns the result of the SUBTOTAL formula over values of cells without SUBTOTAL formulas,
+// false if the aggregation has no result like the average of no numbers or isn't written by Write
+func calcSubtotal(file *excelize.File, sheetName string, formula string, formulas map[string]string) (string, bool, error) {
+	match := subtotalFormula.FindStringSubmatch(formula)
+	function, _ := strconv.Atoi(match[1])
+	fromColumn, fromRow, err := ParseCellName(match[2])
+	if err != nil {
+		return "", false, err
+	}
+	toColumn, toRow, err := ParseCellName(match[3])
+	if err != nil {
+		return "", false, err
+	}
+
+	var numbers []float64
+	count := 0
+	for rowIdx := fromRow; rowIdx <= toRow; rowIdx++ {
+		for columnIdx := fromColumn; columnIdx <= toColumn; columnIdx++ {
+			cell := GetCellName(columnIdx, rowIdx)
+			if subtotalFormula.MatchString(formulas[cell]) {
+				continue
+			}
+			value, err := file.GetCellValue(sheetName, cell, excelize.Options{RawCellValue: true})
+			if err != nil {
+				return "", false, err
+			}
+			if value == "" {
+				continue
+			}
+			count++
+			if number, err := strconv.ParseFloat(value, 64); err == nil {
+				numbers = append(numbers, number)
+			}
+		}
+	}
+
+	var result float64
+	switch function {
+	case subtotalFunctions["count"]:
+		result = float64(count)
+	case subtotalFunctions["sum"]:
+		for _, number := range numbers {
+			result += number
+		}
+	case subtotalFunctions["avg"]:
+		if len(numbers) == 0 {
+			return "", false, nil
+		}
+		for _, number := range numbers {
+			result += number
+		}
+		result /= float64(len(numbers))
+	case subtotalFunctions["min"], subtotalFunctions["max"]:
+		if len(numbers) == 0 {
+			// Excel shows 0 for MIN and MAX of no numbers
+			return "0", true, nil
+		}
+		result = numbers[0]
+		for _, number := range numbers[1:] {
+			if function == subtotalFunctions["min"] {
+				result = math.Min(result, number)
+			} else {
+				result = math.Max(result, number)
+			}
+		}
+	default:
+		return "", false, nil
+	}
+	return strconv.FormatFloat(result, 'f', -1, 64), true, nil
+}
@@ -0,0 +1,64 @@
+package xlsx
+
+import (
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestPrecalcGroupedTotals(t *testing.T) {
+	type row struct {
+		Region string
+		Qty    int `xlsx:"summary:sum;total:sum"`
+		Double int `xlsx:"formula:={Qty}*2"`
+	}
+	data := []row{{"a", 1, 0}, {"a", 2, 0}, {"b", 3, 0}}
+
+	file := excelize.NewFile()
+	if err := Write(file, "Data", data, WithGrouping(Grouping{GroupBy: "Region"}), WithPrecalc()); err != nil {
+		t.Fatal(err)
+	}
+	file = reopen(t, file)
+
+	// Header, totals, a rows and their summary, b row and its summary
+	for cell, want := range map[string]string{"B2": "6", "B5": "3", "B7": "3", "C3": "2", "C6": "6"} {
+		got, err := file.GetCellValue("Data", cell)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("%s is %q, want %q", cell, got, want)
+		}
+		formula, err := file.GetCellFormula("Data", cell)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if formula == "" {
+			t.Errorf("%s lost its formula", cell)
+		}
+	}
+}
+
+func TestFullCalcOnLoad(t *testing.T) {
+	type row struct {
+		Qty int `xlsx:"total:sum"`
+	}
+	file := excelize.NewFile()
+	if err := Write(file, "Data", []row{{1}, {2}}, WithFullCalcOnLoad()); err != nil {
+		t.Fatal(err)
+	}
+	// The workbook is loaded by GetSheetList
+	file = reopen(t, file)
+	file.GetSheetList()
+	if calcPr := file.WorkBook.CalcPr; calcPr == nil || !calcPr.FullCalcOnLoad {
+		t.Fatalf("full calculation on load isn't set: %+v", calcPr)
+	}
+
+	// The workbook has no calculation properties after UpdateLinkedValue
+	if err := file.UpdateLinkedValue(); err != nil {
+		t.Fatal(err)
+	}
+	if err := Write(file, "Data", []row{{1}}, WithFullCalcOnLoad()); err == nil {
+		t.Fatal("a workbook without calculation properties must fail")
+	}
+}
@@ -0,0 +1,76 @@
+package xlsx
+
+import (
+	"github.com/xuri/excelize/v2"
+)
+
+// WithProgress sets the hook called after every row written by Write or read by Unmarshal,
+// e.g. to show a progress bar of big imports and exports
+// Write reports written elements of the slice, Unmarshal reports read rows of the sheet
+func WithProgress(fn func(rowsDone, rowsTotal int)) Option {
+	return func(o *options) {
+		o.write.OnProgress = fn
+		o.read.OnProgress = fn
+	}
+}
+
+// progress counts done rows for the progress hook, nil without the hook
+type progress struct {
+	fn    func(rowsDone, rowsTotal int)
+	done  int
+	total int
+}
+
+func newProgress(fn func(rowsDone, rowsTotal int), total int) *progress {
+	if fn == nil {
+		return nil
+	}
+	return &progress{fn: fn, total: total}
+}
+
+// newReadProgress returns the progress of reading the sheet, rows of excelize files are counted in advance
+func newReadProgress(file *excelize.File, sheetName string, rows *rowReader, fn func(rowsDone, rowsTotal int)) (*progress, error) {
+	if fn == nil {
+		return nil, nil
+	}
+	if file == nil {
+		return newProgress(fn, rows.rows), nil
+	}
+	if rows.cached != nil {
+		return newProgress(fn, len(rows.cached.raw)), nil
+	}
+
+	it, err := file.Rows(sheetName)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+	total := 0
+	for it.Next() {
+		total++
+	}
+	return newProgress(fn, total), nil
+}
+
+// set reports the number of done rows
+func (p *progress) set(done int) {
+	if p == nil {
+		return
+	}
+	p.done = done
+	p.fn(p.done, p.total)
+}
+
+// add reports one more done row
+func (p *progress) add() {
+	if p != nil {
+		p.set(p.done + 1)
+	}
+}
+
+// finish reports all rows done if reading stopped before the end, e.g. at the empty row gap
+func (p *progress) finish() {
+	if p != nil && p.done < p.total {
+		p.set(p.total)
+	}
+}
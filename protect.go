@@ -0,0 +1,103 @@
+package xlsx
+
+import (
+	"fmt"
+	"hash/fnv"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// checksumHeader is the header of the hidden column with checksums of locked cells
+const checksumHeader = "#checksum"
+
+// WithSheetPassword sets the password of sheets protected because of locked columns
+func WithSheetPassword(password string) Option {
+	return func(o *options) {
+		o.write.SheetPassword = password
+	}
+}
+
+// lockedColumns returns columns with the locked tag
+func lockedColumns(columns []column) []column {
+	var locked []column
+	for _, c := range columns {
+		if getTagBool(c.field, "locked") {
+			locked = append(locked, c)
+		}
+	}
+	return locked
+}
+
+// checksumColumnIndex returns the index of the hidden checksum column right after the last column
+func checksumColumnIndex(columns []column) int {
+	return lastColumnIndex(columns) + 1
+}
+
+// unlockInputColumns makes columns without the locked tag editable, rows added later included
+// It must be called before any cell is written, because column styles replace cell styles
+func unlockInputColumns(file *excelize.File, sheetName string, columns []column, styles *sheetStyles) error {
+	for _, c := range columns {
+		style, ok := styles.inputs[c.index]
+		if !ok {
+			continue
+		}
+		letter := getColumnLetter(c.index)
+		if err := file.SetColStyle(sheetName, letter, style); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// protectSheet hides the checksum column and protects the sheet
+func protectSheet(file *excelize.File, sheetName string, columns []column, headerRow int, password string) error {
+	letter := getColumnLetter(checksumColumnIndex(columns))
+	if err := file.SetCellValue(sheetName, letter+fmt.Sprint(headerRow), checksumHeader); err != nil {
+		return err
+	}
+	if err := file.SetColVisible(sheetName, letter, false); err != nil {
+		return err
+	}
+	return file.ProtectSheet(sheetName, &excelize.SheetProtectionOptions{
+		Password:            password,
+		SelectLockedCells:   true,
+		SelectUnlockedCells: true,
+	})
+}
+
+// writeRowProtection unlocks input cells of the row and writes the checksum of its locked cells
+func writeRowProtection(file *excelize.File, sheetName string, columns []column, locked []column, rowIdx int, styles *sheetStyles) error {
+	for _, c := range columns {
+		if style, ok := styles.inputs[c.index]; ok {
+			cell := GetCellName(c.index, rowIdx)
+			file.SetCellStyle(sheetName, cell, cell, style)
+		}
+	}
+
+	checksum, err := rowChecksum(file, sheetName, locked, rowIdx)
+	if err != nil {
+		return err
+	}
+	return file.SetCellValue(sheetName, GetCellName(checksumColumnIndex(columns), rowIdx), checksum)
+}
+
+// rowChecksum returns the hash of formulas or raw values of locked cells of the row
+func rowChecksum(file *excelize.File, sheetName string, locked []column, rowIdx int) (string, error) {
+	h := fnv.New64a()
+	for _, c := range locked {
+		cell := GetCellName(c.index, rowIdx)
+		value, err := file.GetCellFormula(sheetName, cell)
+		if err != nil {
+			return "", err
+		}
+		if value == "" {
+			value, err = file.GetCellValue(sheetName, cell, excelize.Options{RawCellValue: true})
+			if err != nil {
+				return "", err
+			}
+		}
+		h.Write([]byte(value))
+		h.Write([]byte{0})
+	}
+	return fmt.Sprintf("%016x", h.Sum64()), nil
+}
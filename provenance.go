@@ -0,0 +1,59 @@
+package xlsx
+
+import (
+	"github.com/xuri/excelize/v2"
+)
+
+// provenanceHeaders are headers of hidden provenance columns
+var provenanceHeaders = []string{"#source_row", "#batch"}
+
+// Provenance traces rows converted from one workbook to another
+// Unmarshal records source rows, Write puts them with the batch ID to hidden columns
+type Provenance struct {
+	// BatchID identifies the import
+	BatchID string
+	// SourceSheet is the sheet read last
+	SourceSheet string
+	// Rows are source row indexes of read elements in the slice order
+	Rows []int
+}
+
+// WithProvenance records source rows on Unmarshal and writes them on Write
+// Write expects elements in the order they were read, rows spread by SheetRouter keep their source rows
+func WithProvenance(p *Provenance) Option {
+	return func(o *options) {
+		o.write.Provenance = p
+		o.read.Provenance = p
+	}
+}
+
+// provenanceColumnIndex returns the index of the first provenance column
+// It comes after the checksum column of locked columns
+func provenanceColumnIndex(columns []column) int {
+	return checksumColumnIndex(columns) + 1
+}
+
+// writeProvenanceHeader writes headers of provenance columns and hides them
+func writeProvenanceHeader(file *excelize.File, sheetName string, columns []column, headerRow int) error {
+	for i, header := range provenanceHeaders {
+		columnIdx := provenanceColumnIndex(columns) + i
+		if err := file.SetCellValue(sheetName, GetCellName(columnIdx, headerRow), header); err != nil {
+			return err
+		}
+		if err := file.SetColVisible(sheetName, getColumnLetter(columnIdx), false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeProvenance writes the source row of the rowi element of the slice passed to Write and the batch ID
+func writeProvenance(file *excelize.File, sheetName string, columns []column, rowi int, rowIdx int, p *Provenance) error {
+	columnIdx := provenanceColumnIndex(columns)
+	if rowi < len(p.Rows) {
+		if err := file.SetCellValue(sheetName, GetCellName(columnIdx, rowIdx), p.Rows[rowi]); err != nil {
+			return err
+		}
+	}
+	return file.SetCellValue(sheetName, GetCellName(columnIdx+1, rowIdx), p.BatchID)
+}
@@ -0,0 +1,38 @@
+package xlsx
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestProvenanceSheetRouter(t *testing.T) {
+	type row struct {
+		Sheet string
+		Name  string
+	}
+	data := []row{{"X", "a"}, {"Y", "b"}, {"X", "c"}}
+	p := &Provenance{BatchID: "batch", Rows: []int{10, 11, 12}}
+
+	file := excelize.NewFile()
+	router := WithSheetRouter(func(v interface{}) string { return v.(row).Sheet })
+	if err := Write(file, "Data", data, router, WithProvenance(p)); err != nil {
+		t.Fatal(err)
+	}
+
+	for sheetName, want := range map[string][]string{"X": {"10", "12"}, "Y": {"11"}} {
+		rows, err := file.GetRows(sheetName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		columnIdx := provenanceColumnIndex(getColumns(reflect.TypeOf(row{})))
+		var got []string
+		for _, r := range rows[1:] {
+			got = append(got, cellAt(r, columnIdx))
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("sheet %s: source rows %v, want %v", sheetName, got, want)
+		}
+	}
+}
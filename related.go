@@ -0,0 +1,151 @@
+package xlsx
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// UnmarshalRelated reads parents from parentSheet into v and children from childSheet
+// into the parent slice field with the children tag, e.g. orders and their lines
+// Children are joined with parents by the key column, the parent struct must have a field read from it,
+// children without one get keys from the column of their sheet, like the one Write adds for them
+// Children with keys of no parent are returned in the error, parents are filled anyway
+func UnmarshalRelated(file *excelize.File, parentSheet string, childSheet string, key string, v interface{}, opts ...Option) error {
+	o := newOptions(opts)
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("pointer to slice is required")
+	}
+	elemType := rv.Elem().Type().Elem()
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return fmt.Errorf("slice of structs only is allowed")
+	}
+
+	children, childType, ok := childrenField(elemType)
+	if !ok {
+		return fmt.Errorf("%s has no slice field with the children tag", elemType)
+	}
+	parentKey, ok := findKeyColumn(elemType, key)
+	if !ok {
+		return fmt.Errorf("%s has no field for the key column %s", elemType, key)
+	}
+	childKey, ownKey := findKeyColumn(childType, key)
+
+	for _, sheetName := range []string{parentSheet, childSheet} {
+		if index, err := file.GetSheetIndex(sheetName); err != nil || index < 0 {
+			return fmt.Errorf("sheet %q not found", sheetName)
+		}
+	}
+
+	// Detail rows are in their own sheet, so the parent sheet is read flat
+	parentOptions := o.read.withDefaults()
+	parentOptions.flat = true
+	if err := unmarshalSheetTyped(file, parentSheet, rv.Elem(), parentOptions); err != nil {
+		return err
+	}
+
+	childOptions := o.read.withDefaults()
+	childOptions.Provenance = nil
+	if !ownKey {
+		childOptions.Provenance = &Provenance{}
+	}
+	childSlice := reflect.New(children.Type)
+	if err := unmarshalSheetTyped(file, childSheet, childSlice.Elem(), childOptions); err != nil {
+		return err
+	}
+	var keysByRow map[int]string
+	if !ownKey {
+		var err error
+		if keysByRow, err = readRelatedKeys(file, childSheet, parentKey, o.read); err != nil {
+			return err
+		}
+	}
+
+	parents := map[string][]reflect.Value{}
+	for i := 0; i < rv.Elem().Len(); i++ {
+		parent := reflect.Indirect(rv.Elem().Index(i))
+		k := fmt.Sprint(parent.FieldByIndex(parentKey.field.Index).Interface())
+		parents[k] = append(parents[k], parent)
+	}
+
+	var orphans []string
+	for i := 0; i < childSlice.Elem().Len(); i++ {
+		child := childSlice.Elem().Index(i)
+		var k string
+		if ownKey {
+			k = fmt.Sprint(reflect.Indirect(child).FieldByIndex(childKey.field.Index).Interface())
+		} else {
+			k = keysByRow[childOptions.Provenance.Rows[i]]
+		}
+		if len(parents[k]) == 0 {
+			orphans = append(orphans, k)
+			continue
+		}
+		for _, parent := range parents[k] {
+			field := parent.FieldByIndex(children.Index)
+			field.Set(reflect.Append(field, child))
+		}
+	}
+
+	if len(orphans) > 0 {
+		return fmt.Errorf("sheet %s: %d rows with keys of no parent: %s", childSheet, len(orphans), strings.Join(orphans, ", "))
+	}
+	return nil
+}
+
+// readRelatedKeys reads the key column of the sheet as the parent key field by rows
+func readRelatedKeys(file *excelize.File, sheetName string, parentKey column, opts UnmarshalOptions) (map[int]string, error) {
+	keyType := reflect.StructOf([]reflect.StructField{{
+		Name: "Key",
+		Type: parentKey.field.Type,
+		Tag:  reflect.StructTag(fmt.Sprintf(`xlsx:"name:%s"`, strings.Join(parentKey.aliases, "|"))),
+	}})
+
+	// Only options finding the header and the rows apply to the key column
+	o := UnmarshalOptions{
+		HeaderRow:              opts.HeaderRow,
+		DataStartRow:           opts.DataStartRow,
+		MaxColumns:             opts.MaxColumns,
+		EmptyRowGap:            opts.EmptyRowGap,
+		EmptyHeaderGap:         opts.EmptyHeaderGap,
+		NoHeader:               opts.NoHeader,
+		NormalizeHeaders:       opts.NormalizeHeaders,
+		StripHeaderPunctuation: opts.StripHeaderPunctuation,
+		HeaderMatcher:          opts.HeaderMatcher,
+		FuzzyHeaders:           opts.FuzzyHeaders,
+		FoldHeaders:            opts.FoldHeaders,
+		NormalizeUnicode:       opts.NormalizeUnicode,
+		ScrubStrings:           opts.ScrubStrings,
+		Cache:                  opts.Cache,
+		Provenance:             &Provenance{},
+	}.withDefaults()
+	keys := reflect.New(reflect.SliceOf(keyType))
+	if err := unmarshalSheetTyped(file, sheetName, keys.Elem(), o); err != nil {
+		return nil, err
+	}
+
+	keysByRow := make(map[int]string, keys.Elem().Len())
+	for i := 0; i < keys.Elem().Len(); i++ {
+		keysByRow[o.Provenance.Rows[i]] = fmt.Sprint(keys.Elem().Index(i).Field(0).Interface())
+	}
+	return keysByRow, nil
+}
+
+// findKeyColumn returns the column of the struct type read from the column with the name
+func findKeyColumn(t reflect.Type, name string) (column, bool) {
+	for _, c := range getColumns(t) {
+		for _, alias := range c.aliases {
+			if alias == name {
+				return c, true
+			}
+		}
+	}
+	return column{}, false
+}
@@ -0,0 +1,36 @@
+package xlsx
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestUnmarshalRelatedWrittenChildren(t *testing.T) {
+	type line struct {
+		Product string
+		Qty     int
+	}
+	type order struct {
+		ID    int    `xlsx:"key"`
+		Lines []line `xlsx:"children;sheet:Lines"`
+	}
+	orders := []order{
+		{ID: 1, Lines: []line{{"a", 1}, {"b", 2}}},
+		{ID: 2, Lines: []line{{"c", 3}}},
+	}
+
+	file := excelize.NewFile()
+	if err := Write(file, "Orders", orders); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []order
+	if err := UnmarshalRelated(reopen(t, file), "Orders", "Lines", "ID", &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, orders) {
+		t.Fatalf("got %v, want %v", got, orders)
+	}
+}
@@ -0,0 +1,26 @@
+package xlsx
+
+// ImportReport collects details of Unmarshal worth showing to users, see WithImportReport
+type ImportReport struct {
+	// FuzzyHeaders are headers with typos matched with column names, see WithFuzzyHeaders
+	FuzzyHeaders []FuzzyHeader
+	// Coercions are conversions of read cells, see WithCoercionTrace
+	Coercions []Coercion
+}
+
+// FuzzyHeader is the sheet header matched with the column name despite typos
+type FuzzyHeader struct {
+	Header string
+	// Column is the column name or its alias the header is matched with
+	Column string
+	Field  string
+	// Distance is the number of typos
+	Distance int
+}
+
+// WithImportReport collects details of the import to the report
+func WithImportReport(r *ImportReport) Option {
+	return func(o *options) {
+		o.read.Report = r
+	}
+}
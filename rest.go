@@ -0,0 +1,89 @@
+package xlsx
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// restField returns the map[string]string field with the rest tag
+// Unmarshal puts cells of columns not matched to other fields to it by header,
+// Write adds its keys as columns after other columns
+func restField(t reflect.Type) (reflect.StructField, bool) {
+	mapType := reflect.TypeOf(map[string]string{})
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if getTagBool(field, "rest") && field.Type == mapType {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// appendRestColumns appends columns for keys of rest maps of all elements in sorted order
+func appendRestColumns(columns []column, slice reflect.Value) []column {
+	field, ok := restField(slice.Type().Elem())
+	if !ok {
+		return columns
+	}
+
+	keys := map[string]bool{}
+	for i := 0; i < slice.Len(); i++ {
+		for _, key := range slice.Index(i).FieldByIndex(field.Index).MapKeys() {
+			keys[key.String()] = true
+		}
+	}
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	// columns are cached, so they are copied before appending
+	next := lastColumnIndex(columns) + 1
+	restColumns := append([]column{}, columns...)
+	for i, key := range sorted {
+		restColumns = append(restColumns, column{
+			field:   field,
+			index:   next + i,
+			name:    key,
+			aliases: []string{key},
+			restKey: key,
+		})
+	}
+	return restColumns
+}
+
+// mapRestColumns returns columns of the header not in mapped for the rest field
+// Columns of headers starting with # are written by Write for itself and are skipped
+func mapRestColumns(elemType reflect.Type, h *header, mapped ...[]column) []column {
+	field, ok := restField(elemType)
+	if !ok || h == nil {
+		return nil
+	}
+
+	used := map[int]bool{}
+	for _, columns := range mapped {
+		for _, c := range columns {
+			used[c.index] = true
+		}
+	}
+
+	var rest []column
+	for _, hn := range h.names {
+		if used[hn.columnIdx] || strings.HasPrefix(hn.name, "#") {
+			continue
+		}
+		used[hn.columnIdx] = true
+		rest = append(rest, column{field: field, index: hn.columnIdx, name: hn.name, aliases: []string{hn.name}, restKey: hn.name})
+	}
+	return rest
+}
+
+// setRestValue puts the value to the rest map under the key
+func setRestValue(m reflect.Value, key string, value string) {
+	if m.IsNil() {
+		m.Set(reflect.MakeMap(m.Type()))
+	}
+	m.SetMapIndex(reflect.ValueOf(key), reflect.ValueOf(value))
+}
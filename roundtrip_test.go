@@ -0,0 +1,252 @@
+package xlsx
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type roundTripRow struct {
+	Int   int64
+	Uint  uint64
+	Float float64
+	Time  time.Time
+}
+
+// Generate implements quick.Generator, times are whole seconds of years 1000-9999 like Write keeps them
+func (roundTripRow) Generate(r *rand.Rand, size int) reflect.Value {
+	minSec := time.Date(1000, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+	maxSec := time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC).Unix()
+	return reflect.ValueOf(roundTripRow{
+		Int:   r.Int63() - r.Int63(),
+		Uint:  r.Uint64(),
+		Float: (r.Float64() - 0.5) * float64(r.Int63()),
+		Time:  time.Unix(minSec+r.Int63n(maxSec-minSec), 0).UTC(),
+	})
+}
+
+// reopen saves the file and opens it again so values go through the XML
+func reopen(t *testing.T, file *excelize.File) *excelize.File {
+	t.Helper()
+	var b bytes.Buffer
+	if err := file.Write(&b); err != nil {
+		t.Fatal(err)
+	}
+	reopened, err := excelize.OpenReader(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return reopened
+}
+
+func TestRoundTripProperty(t *testing.T) {
+	for _, date1904 := range []bool{false, true} {
+		for _, numFmt := range []string{"", "#,##0.00", "0.00E+00", "yyyy-mm-dd"} {
+			roundTrip := func(rows []roundTripRow) bool {
+				file := excelize.NewFile()
+				var opts []Option
+				if date1904 {
+					opts = append(opts, WithDate1904())
+				}
+				if err := Write(file, "Data", rows, opts...); err != nil {
+					t.Fatal(err)
+				}
+				if isDate1904(file) != date1904 {
+					t.Fatalf("date1904 is %v, want %v", !date1904, date1904)
+				}
+				if numFmt != "" {
+					style, err := file.NewStyle(&excelize.Style{CustomNumFmt: &numFmt})
+					if err != nil {
+						t.Fatal(err)
+					}
+					if err := file.SetColStyle("Data", "A:D", style); err != nil {
+						t.Fatal(err)
+					}
+				}
+
+				var got []roundTripRow
+				if err := Unmarshal(reopen(t, file), &got, WithUnmarshalOptions(UnmarshalOptions{Strict: true})); err != nil {
+					t.Errorf("date1904 %v, format %q: %v", date1904, numFmt, err)
+					return false
+				}
+				if len(rows) == 0 {
+					return len(got) == 0
+				}
+				if !reflect.DeepEqual(rows, got) {
+					t.Errorf("date1904 %v, format %q:\nwrote %v\nread  %v", date1904, numFmt, rows, got)
+					return false
+				}
+				return true
+			}
+			if err := quick.Check(roundTrip, &quick.Config{MaxCount: 20}); err != nil {
+				t.Error(err)
+			}
+		}
+	}
+}
+
+// Date cells of serial numbers read back the same time in both date systems
+func TestSerialTimeProperty(t *testing.T) {
+	type row struct {
+		Time time.Time
+	}
+	for _, date1904 := range []bool{false, true} {
+		serialTime := func(r roundTripRow) bool {
+			// Serial numbers of the 1904 system start in 1904
+			want := r.Time
+			if want.Year() < 1904 {
+				want = want.AddDate(1904-want.Year(), 0, 0)
+			}
+
+			file := excelize.NewFile()
+			if err := file.SetWorkbookProps(&excelize.WorkbookPropsOptions{Date1904: &date1904}); err != nil {
+				t.Fatal(err)
+			}
+			file.SetCellValue("Sheet1", "A1", "Time")
+			if err := file.SetCellValue("Sheet1", "A2", want); err != nil {
+				t.Fatal(err)
+			}
+
+			var got []row
+			if err := Unmarshal(reopen(t, file), &got, WithUnmarshalOptions(UnmarshalOptions{Strict: true})); err != nil {
+				t.Errorf("date1904 %v: %v", date1904, err)
+				return false
+			}
+			if len(got) != 1 || !got[0].Time.Equal(want) {
+				t.Errorf("date1904 %v: wrote %v, read %v", date1904, want, got)
+				return false
+			}
+			return true
+		}
+		if err := quick.Check(serialTime, &quick.Config{MaxCount: 50}); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestTotalsRoundTrip(t *testing.T) {
+	type row struct {
+		Name string
+		Qty  int
+	}
+	data := []row{{"a", 1}, {"b", 2}, {"c", 3}}
+
+	file := excelize.NewFile()
+	err := Write(file, "Data", data, WithTotals(map[string]string{"Qty": "sum"}), WithTypeRow(), WithPrecalc(), WithAutoFilter())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file = reopen(t, file)
+	var got []row
+	if err := UnmarshalSheet(file, "Data", &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, data) {
+		t.Fatalf("got %v, want %v", got, data)
+	}
+
+	dec, err := NewDecoder(file, "Data")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dec.Close()
+	var decoded []row
+	for dec.Next() {
+		var r row
+		if err := dec.Scan(&r); err != nil {
+			t.Fatal(err)
+		}
+		decoded = append(decoded, r)
+	}
+	if !reflect.DeepEqual(decoded, data) {
+		t.Fatalf("decoded %v, want %v", decoded, data)
+	}
+}
+
+func TestGroupingRoundTrip(t *testing.T) {
+	type row struct {
+		Region string
+		Qty    int `xlsx:"summary:sum"`
+	}
+	data := []row{{"a", 1}, {"a", 2}, {"b", 3}}
+
+	for _, above := range []bool{false, true} {
+		file := excelize.NewFile()
+		if err := Write(file, "Data", data, WithGrouping(Grouping{GroupBy: "Region", SummaryAbove: above}), WithPrecalc()); err != nil {
+			t.Fatal(err)
+		}
+		file = reopen(t, file)
+
+		var got []row
+		if err := UnmarshalSheet(file, "Data", &got); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, data) {
+			t.Fatalf("summary above %v: got %v, want %v", above, got, data)
+		}
+
+		dec, err := NewDecoder(file, "Data")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded []row
+		for dec.Next() {
+			var r row
+			if err := dec.Scan(&r); err != nil {
+				t.Fatal(err)
+			}
+			decoded = append(decoded, r)
+		}
+		dec.Close()
+		if !reflect.DeepEqual(decoded, data) {
+			t.Fatalf("summary above %v: decoded %v, want %v", above, decoded, data)
+		}
+	}
+}
+
+func TestFooterRoundTrip(t *testing.T) {
+	type row struct {
+		Name string
+	}
+	data := []row{{"a"}, {"b"}}
+
+	file := excelize.NewFile()
+	if err := Write(file, "Data", data, WithFooterRows([]string{"foot", "note"})); err != nil {
+		t.Fatal(err)
+	}
+	file = reopen(t, file)
+
+	var got []row
+	if err := UnmarshalSheet(file, "Data", &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, data) {
+		t.Fatalf("got %v, want %v", got, data)
+	}
+
+	dec, err := NewDecoder(file, "Data")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dec.Close()
+	var decoded []row
+	for dec.Next() {
+		var r row
+		if err := dec.Scan(&r); err != nil {
+			t.Fatal(err)
+		}
+		decoded = append(decoded, r)
+	}
+	if dec.Next() {
+		t.Fatal("Next reads past the footer")
+	}
+	if !reflect.DeepEqual(decoded, data) {
+		t.Fatalf("decoded %v, want %v", decoded, data)
+	}
+}
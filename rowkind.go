@@ -0,0 +1,50 @@
+package xlsx
+
+import (
+	"github.com/xuri/excelize/v2"
+)
+
+// rowKindHeader is the header of the hidden column marking written rows which aren't data
+const rowKindHeader = "#row"
+
+// Kinds of rows in the row kind column, Unmarshal skips them
+const (
+	totalsRowKind  = "totals"
+	summaryRowKind = "summary"
+	// Unmarshal stops at the footer
+	footerRowKind = "footer"
+)
+
+// rowKindColumnIndex returns the index of the row kind column
+// It comes after provenance columns
+func rowKindColumnIndex(columns []column) int {
+	return provenanceColumnIndex(columns) + len(provenanceHeaders)
+}
+
+// marksRows reports whether the sheet written with the options has the row kind column
+func (o WriteOptions) marksRows(columns []column) bool {
+	return hasTotals(columns, o) || o.Grouping.GroupBy != "" || len(o.FooterRows) > 0
+}
+
+// writeRowKindHeader writes the header of the row kind column and hides it
+func writeRowKindHeader(file *excelize.File, sheetName string, columns []column, headerRow int) error {
+	columnIdx := rowKindColumnIndex(columns)
+	if err := file.SetCellValue(sheetName, GetCellName(columnIdx, headerRow), rowKindHeader); err != nil {
+		return err
+	}
+	return file.SetColVisible(sheetName, getColumnLetter(columnIdx), false)
+}
+
+// markRow writes the kind of the row to the row kind column
+func markRow(file *excelize.File, sheetName string, columns []column, rowIdx int, kind string) error {
+	return file.SetCellValue(sheetName, GetCellName(rowKindColumnIndex(columns), rowIdx), kind)
+}
+
+// rowKind returns the kind of the row marked by Write, empty for data rows and sheets without the row kind column
+func (h *header) rowKind(raw []string) string {
+	columnIdx, ok := h.find(rowKindHeader)
+	if !ok {
+		return ""
+	}
+	return cellAt(raw, columnIdx)
+}
@@ -0,0 +1,125 @@
+package xlsx
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// checkRules returns violations of validation tags of the decoded element:
+// nonzero - the field can't be zero, e.g. the cell can't be empty
+// min, max - limits of numbers, of lengths of strings and of times like "2006-01-02", zero values aren't checked
+// regexp - the pattern the text of the field must match, it can't contain ";"
+// enum - values of the field separated by "|" like "new|paid|shipped"
+func checkRules(element reflect.Value, c column) ([]error, error) {
+	value := element.FieldByIndex(c.field.Index)
+	var problems []error
+	if value.IsZero() {
+		if getTagBool(c.field, "nonzero") {
+			problems = append(problems, errors.New("value is required"))
+		}
+		return problems, nil
+	}
+	for value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+
+	for _, limit := range []string{"min", "max"} {
+		bound := getTag(c.field, limit)
+		if bound == "" {
+			continue
+		}
+		cmp, err := compareBound(value, bound)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %s tag: %w", c.field.Name, limit, err)
+		}
+		if limit == "min" && cmp < 0 {
+			problems = append(problems, fmt.Errorf("less than the minimum %s", bound))
+		}
+		if limit == "max" && cmp > 0 {
+			problems = append(problems, fmt.Errorf("greater than the maximum %s", bound))
+		}
+	}
+
+	text := fmt.Sprint(value.Interface())
+	if pattern := getTag(c.field, "regexp"); pattern != "" {
+		re, err := compileRule(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: regexp tag: %w", c.field.Name, err)
+		}
+		if !re.MatchString(text) {
+			problems = append(problems, fmt.Errorf("doesn't match %s", pattern))
+		}
+	}
+	if enum := getTag(c.field, "enum"); enum != "" {
+		found := false
+		for _, allowed := range strings.Split(enum, "|") {
+			if allowed == text {
+				found = true
+				break
+			}
+		}
+		if !found {
+			problems = append(problems, fmt.Errorf("not one of %s", strings.ReplaceAll(enum, "|", ", ")))
+		}
+	}
+	return problems, nil
+}
+
+// compareBound compares the number, the length of the string or the time with the bound, -1 if the value is less
+func compareBound(value reflect.Value, bound string) (int, error) {
+	if t, ok := value.Interface().(time.Time); ok {
+		b, err := time.ParseInLocation("2006-01-02", bound, t.Location())
+		if err != nil {
+			return 0, err
+		}
+		return compareFloats(float64(t.Unix()), float64(b.Unix())), nil
+	}
+
+	b, err := strconv.ParseFloat(bound, 64)
+	if err != nil {
+		return 0, err
+	}
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return compareFloats(float64(value.Int()), b), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return compareFloats(float64(value.Uint()), b), nil
+	case reflect.Float32, reflect.Float64:
+		return compareFloats(value.Float(), b), nil
+	case reflect.String:
+		return compareFloats(float64(utf8.RuneCountInString(value.String())), b), nil
+	}
+	return 0, fmt.Errorf("%s has no limits", value.Type())
+}
+
+func compareFloats(a float64, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// rulePatterns caches compiled patterns of regexp tags
+var rulePatterns sync.Map
+
+func compileRule(pattern string) (*regexp.Regexp, error) {
+	if cached, ok := rulePatterns.Load(pattern); ok {
+		return cached.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	rulePatterns.Store(pattern, re)
+	return re, nil
+}
@@ -0,0 +1,123 @@
+package xlsx
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// schemaSheet is the very hidden sheet with column schemas of sheets written with WithSchema
+const schemaSheet = "xlsx_schema"
+
+// WithSchema saves names, order and types of written columns to the very hidden schema sheet,
+// UnmarshalOptions.VerifySchema refuses files which don't match it
+func WithSchema() Option {
+	return func(o *options) {
+		o.write.Schema = true
+	}
+}
+
+// schemaColumn is a column of the saved schema
+type schemaColumn struct {
+	index int
+	name  string
+	kind  string
+}
+
+// writeSchema replaces the schema of the sheet in the schema sheet
+func writeSchema(file *excelize.File, sheetName string, columns []column) error {
+	ensureSheet(file, schemaSheet)
+
+	oldRows, err := file.GetRows(schemaSheet)
+	if err != nil {
+		return err
+	}
+
+	rows := [][]interface{}{{"Sheet", "Column", "Name", "Type"}}
+	for rowi, row := range oldRows {
+		if rowi > 0 && len(row) == 4 && row[0] != sheetName {
+			rows = append(rows, []interface{}{row[0], row[1], row[2], row[3]})
+		}
+	}
+	for _, c := range columns {
+		hint := c.typeHint()
+		rows = append(rows, []interface{}{sheetName, getColumnLetter(c.index), c.name, hint.String()})
+	}
+
+	for rowi, row := range rows {
+		if err := file.SetSheetRow(schemaSheet, GetCellName(0, rowi+1), &row); err != nil {
+			return err
+		}
+	}
+	for rowIdx := len(oldRows); rowIdx > len(rows); rowIdx-- {
+		if err := file.RemoveRow(schemaSheet, rowIdx); err != nil {
+			return err
+		}
+	}
+	return file.SetSheetVisible(schemaSheet, false, true)
+}
+
+// readSchema returns the saved schema of the sheet, false if there is none
+func readSchema(file *excelize.File, sheetName string) ([]schemaColumn, bool, error) {
+	if index, err := file.GetSheetIndex(schemaSheet); err != nil || index < 0 {
+		return nil, false, nil
+	}
+	rows, err := file.GetRows(schemaSheet)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var schema []schemaColumn
+	for rowi, row := range rows {
+		if rowi == 0 || len(row) != 4 || row[0] != sheetName {
+			continue
+		}
+		number, err := excelize.ColumnNameToNumber(row[1])
+		if err != nil {
+			return nil, false, fmt.Errorf("schema of sheet %s: %w", sheetName, err)
+		}
+		schema = append(schema, schemaColumn{index: number - 1, name: row[2], kind: row[3]})
+	}
+	return schema, len(schema) > 0, nil
+}
+
+// verifySchema compares the header row and the struct columns with the saved schema
+func verifySchema(file *excelize.File, sheetName string, headerRow []string, mapped []column) error {
+	schema, ok, err := readSchema(file, sheetName)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return &SchemaError{SheetName: sheetName, Problems: []string{"no saved schema"}}
+	}
+
+	var problems []string
+	known := map[int]schemaColumn{}
+	for _, sc := range schema {
+		known[sc.index] = sc
+		if header := strings.TrimSpace(cellAt(headerRow, sc.index)); header != sc.name {
+			problems = append(problems, fmt.Sprintf("column %s: header %q, expected %q", getColumnLetter(sc.index), header, sc.name))
+		}
+	}
+	for columnIdx, header := range headerRow {
+		header = strings.TrimSpace(header)
+		if _, ok := known[columnIdx]; !ok && header != "" && !strings.HasPrefix(header, "#") {
+			problems = append(problems, fmt.Sprintf("column %s: unexpected header %q", getColumnLetter(columnIdx), header))
+		}
+	}
+	for _, c := range mapped {
+		sc, ok := known[c.index]
+		if !ok || c.restKey != "" {
+			continue
+		}
+		if kind := c.typeHint().String(); kind != sc.kind {
+			problems = append(problems, fmt.Sprintf("column %s: type %s, field %s is %s", getColumnLetter(c.index), sc.kind, c.field.Name, kind))
+		}
+	}
+
+	if len(problems) > 0 {
+		return &SchemaError{SheetName: sheetName, Problems: problems}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package xlsx
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// WithScrubStrings removes zero-width characters, byte order marks and bidi controls from read cells,
+// non-breaking spaces become spaces and spaces around values are trimmed, headers are scrubbed too
+func WithScrubStrings() Option {
+	return func(o *options) {
+		o.read.ScrubStrings = true
+	}
+}
+
+// WithNormalizeUnicode converts read cells and headers to the NFC form,
+// so letters typed as one character or as a letter with a combining mark are equal
+func WithNormalizeUnicode() Option {
+	return func(o *options) {
+		o.read.NormalizeUnicode = true
+	}
+}
+
+// WithFoldHeaders matches headers ignoring case, spaces and Cyrillic letters looking like Latin ones,
+// e.g. "Рrice" typed with the Cyrillic Р is "PRICE", see UnmarshalOptions.FoldHeaders
+func WithFoldHeaders() Option {
+	return func(o *options) {
+		o.read.FoldHeaders = true
+	}
+}
+
+// cellCleaner returns the function changing read cells, nil if cells are read as they are
+func (o UnmarshalOptions) cellCleaner() func(string) string {
+	switch {
+	case o.ScrubStrings && o.NormalizeUnicode:
+		return func(s string) string {
+			return norm.NFC.String(scrubString(s))
+		}
+	case o.ScrubStrings:
+		return scrubString
+	case o.NormalizeUnicode:
+		return norm.NFC.String
+	}
+	return nil
+}
+
+// cleanRow changes all cells of the row in place
+func cleanRow(row []string, clean func(string) string) {
+	for i, s := range row {
+		row[i] = clean(s)
+	}
+}
+
+// scrubString removes invisible characters of copy-pasted text, see WithScrubStrings
+func scrubString(s string) string {
+	if isASCII(s) {
+		return strings.TrimSpace(s)
+	}
+	return strings.TrimSpace(strings.Map(func(r rune) rune {
+		switch {
+		case r == '\u200B', r == '\u200C', r == '\u200D', r == '\u2060', r == '\uFEFF', r == '\u00AD':
+			// Zero-width spaces and joiners, the word joiner, the byte order mark and the soft hyphen
+			return -1
+		case r == '\u200E', r == '\u200F', r == '\u061C', r >= '\u202A' && r <= '\u202E', r >= '\u2066' && r <= '\u2069':
+			// Bidi marks, embeddings, overrides and isolates
+			return -1
+		case r == '\u00A0', r == '\u2007', r == '\u202F':
+			// Non-breaking spaces
+			return ' '
+		}
+		return r
+	}, s))
+}
+
+// latinLookalikes maps lowercase Cyrillic letters to Latin letters looking the same
+var latinLookalikes = map[rune]rune{
+	'а': 'a', 'в': 'b', 'е': 'e', 'к': 'k', 'м': 'm', 'н': 'h',
+	'о': 'o', 'р': 'p', 'с': 'c', 'т': 't', 'у': 'y', 'х': 'x',
+	'і': 'i', 'ј': 'j', 'ѕ': 's',
+}
+
+// foldHeader folds case of the header and replaces Cyrillic lookalikes of Latin letters in words with Latin letters
+// Words of Cyrillic letters only are kept, so "Цена" and "Сумма" stay different
+func foldHeader(name string) string {
+	name = cases.Fold().String(name)
+	words := strings.Fields(name)
+	for i, word := range words {
+		if hasLatin(word) {
+			words[i] = strings.Map(func(r rune) rune {
+				if latin, ok := latinLookalikes[r]; ok {
+					return latin
+				}
+				return r
+			}, word)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// hasLatin reports whether the word has ASCII letters
+func hasLatin(word string) bool {
+	for i := 0; i < len(word); i++ {
+		if c := word[i]; c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' {
+			return true
+		}
+	}
+	return false
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
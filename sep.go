@@ -0,0 +1,49 @@
+package xlsx
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// joinSeparated returns elements of the slice with the sep tag like `xlsx:"sep:,"` joined by the separator,
+// elements are written like fields of their type, ";" can't be the separator
+func joinSeparated(field reflect.StructField, value reflect.Value) (string, error) {
+	sep := getTag(field, "sep")
+	elemField := reflect.StructField{Name: field.Name, Type: value.Type().Elem()}
+	parts := make([]string, value.Len())
+	for i := range parts {
+		cellValue, err := getCellValue(elemField, value.Index(i))
+		if err != nil {
+			return "", err
+		}
+		parts[i] = fmt.Sprint(cellValue)
+	}
+	return strings.Join(parts, sep), nil
+}
+
+// isSeparatedField reports whether the slice field is written as one cell, see joinSeparated
+func isSeparatedField(field reflect.StructField, value reflect.Value) bool {
+	return getTag(field, "sep") != "" && value.Kind() == reflect.Slice && value.Type().Elem().Kind() != reflect.Uint8
+}
+
+// splitSeparated sets the slice field with the sep tag to parts of the text converted like text cells,
+// spaces around parts are trimmed and empty parts are skipped
+func splitSeparated(value reflect.Value, sep string, text string, date1904 bool) error {
+	slice := reflect.MakeSlice(value.Type(), 0, strings.Count(text, sep)+1)
+	for i, part := range strings.Split(text, sep) {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		elem := reflect.New(value.Type().Elem()).Elem()
+		if err := convertCell(elem, part, part, excelize.CellTypeSharedString, date1904); err != nil {
+			return fmt.Errorf("element %d: %w", i+1, err)
+		}
+		slice = reflect.Append(slice, elem)
+	}
+	value.Set(slice)
+	return nil
+}
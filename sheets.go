@@ -0,0 +1,79 @@
+package xlsx
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// WithSheetOrder sets the order of tabs of sheets written by Write, sheets not listed follow them
+// excelize can't move sheets, so the listed sheets are created first and must all be written by the call
+func WithSheetOrder(sheetNames ...string) Option {
+	return func(o *options) {
+		o.write.SheetOrder = sheetNames
+	}
+}
+
+// WithActiveSheet sets the sheet shown when the file is opened
+func WithActiveSheet(sheetName string) Option {
+	return func(o *options) {
+		o.write.ActiveSheet = sheetName
+	}
+}
+
+// activateSheet activates and selects the sheet
+func activateSheet(file *excelize.File, sheetName string) error {
+	index, err := file.GetSheetIndex(sheetName)
+	if err != nil {
+		return err
+	}
+	if index < 0 {
+		return fmt.Errorf("active sheet %s is not in the workbook", sheetName)
+	}
+	file.SetActiveSheet(index)
+	return nil
+}
+
+// createSheets creates sheets of WithSheetOrder in order before anything is written
+// excelize can't move sheets, so only sheets written by the call can be ordered, other names are an error
+func createSheets(file *excelize.File, sheetName string, slice reflect.Value, r *routes, o *options) error {
+	written := writtenSheets(sheetName, slice, r, o.write)
+	o.created = map[string]bool{}
+	for _, name := range o.write.SheetOrder {
+		if !written[name] {
+			return fmt.Errorf("sheet %s is not written, so it can't be ordered", name)
+		}
+		if o.created[name] {
+			continue
+		}
+		if name == o.write.IndexSheet {
+			// The index keeps data row counts of sheets written before
+			ensureSheet(file, name)
+		} else {
+			file.DeleteSheet(name)
+			file.NewSheet(name)
+		}
+		o.created[name] = true
+	}
+	return nil
+}
+
+// writtenSheets returns names of sheets written by Write, elements are spread by the routes if they aren't nil
+func writtenSheets(sheetName string, slice reflect.Value, r *routes, o WriteOptions) map[string]bool {
+	written := map[string]bool{}
+	if r != nil && len(r.sheetNames) > 0 {
+		for _, name := range r.sheetNames {
+			written[name] = true
+		}
+	} else {
+		written[sheetName] = true
+	}
+	if children, _, ok := childrenField(slice.Type().Elem()); ok {
+		written[childSheetName(children)] = true
+	}
+	if o.IndexSheet != "" {
+		written[o.IndexSheet] = true
+	}
+	return written
+}
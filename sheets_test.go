@@ -0,0 +1,41 @@
+package xlsx
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestSheetOrderCreatesWrittenSheetsInOrder(t *testing.T) {
+	type row struct {
+		Region string
+		Qty    int
+	}
+	file := excelize.NewFile()
+	file.NewSheet("Notes")
+	file.SetActiveSheet(1)
+
+	router := func(v interface{}) string { return v.(row).Region }
+	err := Write(file, "Data", []row{{"East", 1}, {"West", 2}}, WithSheetRouter(router), WithSheetOrder("West", "East"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := file.GetSheetList(), []string{"Notes", "West", "East"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got := file.GetSheetName(file.GetActiveSheetIndex()); got != "Notes" {
+		t.Fatalf("active sheet is %s without WithActiveSheet, want Notes", got)
+	}
+
+	if err := Write(file, "Data", []row{{"East", 1}}, WithSheetRouter(router), WithActiveSheet("East")); err != nil {
+		t.Fatal(err)
+	}
+	if got := file.GetSheetName(file.GetActiveSheetIndex()); got != "East" {
+		t.Fatalf("active sheet is %s, want East", got)
+	}
+
+	if err := Write(file, "Data", []row{{"East", 1}}, WithSheetOrder("Notes", "Data")); err == nil {
+		t.Fatal("ordering a sheet which isn't written must fail")
+	}
+}
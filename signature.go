@@ -0,0 +1,91 @@
+package xlsx
+
+import (
+	"github.com/xuri/excelize/v2"
+)
+
+// SignatureBlock configures rows for signatures below the written data
+type SignatureBlock struct {
+	// Roles are labels of signature rows, "Prepared by" and "Approved by" by default
+	Roles []string
+	// Gap is the number of empty rows between the data and the block, 2 by default
+	Gap int
+	// DatePlaceholder is the text of date cells, "YYYY-MM-DD" by default
+	DatePlaceholder string
+}
+
+func (b SignatureBlock) withDefaults() SignatureBlock {
+	if len(b.Roles) == 0 {
+		b.Roles = []string{"Prepared by", "Approved by"}
+	}
+	if b.Gap < 1 {
+		b.Gap = 2
+	}
+	if b.DatePlaceholder == "" {
+		b.DatePlaceholder = "YYYY-MM-DD"
+	}
+	return b
+}
+
+// AppendSignatureBlock writes signature rows below the data and the footer of the layout
+// Every row has the role, a bordered cell for the name and signature, "Date" and a bordered date cell
+// It returns the range of the block
+func AppendSignatureBlock(file *excelize.File, layout Layout, block SignatureBlock) (CellRange, error) {
+	block = block.withDefaults()
+
+	lastRow := layout.Header.ToRow
+	if layout.Data.Rows() > 0 {
+		lastRow = layout.Data.ToRow
+	}
+	if layout.Footer.ToRow > lastRow {
+		lastRow = layout.Footer.ToRow
+	}
+	firstRow := lastRow + block.Gap + 1
+
+	font := defaultFont
+	labelStyle, _ := file.NewStyle(&excelize.Style{
+		Font:      &font,
+		Alignment: &excelize.Alignment{Horizontal: "right", Vertical: "bottom"},
+	})
+	fieldStyle, _ := file.NewStyle(&excelize.Style{
+		Font:      &font,
+		Border:    []excelize.Border{{Type: "bottom", Color: "#000000", Style: 1}},
+		Alignment: &excelize.Alignment{Vertical: "bottom"},
+	})
+	placeholderFont := defaultFont
+	placeholderFont.Color = "#A6A6A6"
+	placeholderStyle, _ := file.NewStyle(&excelize.Style{
+		Font:      &placeholderFont,
+		Border:    []excelize.Border{{Type: "bottom", Color: "#000000", Style: 1}},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "bottom"},
+	})
+
+	sheetName := layout.SheetName
+	for i, role := range block.Roles {
+		rowIdx := firstRow + i*2
+		cells := []struct {
+			value interface{}
+			style int
+		}{
+			{role + ":", labelStyle},
+			{"", fieldStyle},
+			{"Date:", labelStyle},
+			{block.DatePlaceholder, placeholderStyle},
+		}
+		for columnIdx, c := range cells {
+			cell := GetCellName(columnIdx, rowIdx)
+			if err := file.SetCellValue(sheetName, cell, c.value); err != nil {
+				return CellRange{}, err
+			}
+			file.SetCellStyle(sheetName, cell, cell, c.style)
+		}
+		file.SetRowHeight(sheetName, rowIdx, 24)
+	}
+
+	return CellRange{
+		FromColumn: 0,
+		FromRow:    firstRow,
+		ToColumn:   3,
+		ToRow:      firstRow + (len(block.Roles)-1)*2,
+	}, nil
+}
@@ -0,0 +1,177 @@
+package xlsx
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// CellSource is a sheet backend Unmarshal can read from, see UnmarshalSource
+// Cell names are in A1 notation
+type CellSource interface {
+	// GetCellValue returns the formatted cell value, the raw one with excelize.Options{RawCellValue: true}
+	GetCellValue(sheet string, cell string, opts ...excelize.Options) (string, error)
+	// GetCellType returns the cell type, excelize.CellTypeUnset makes Unmarshal guess it by the value
+	GetCellType(sheet string, cell string) (excelize.CellType, error)
+	// Dimensions returns the number of used rows and columns of the sheet
+	Dimensions(sheet string) (rows int, columns int, err error)
+}
+
+// FileSource is the CellSource of an excelize file
+type FileSource struct {
+	*excelize.File
+}
+
+// Dimensions returns the number of rows and the length of the longest row
+func (s FileSource) Dimensions(sheet string) (int, int, error) {
+	rows, err := s.File.GetRows(sheet)
+	if err != nil {
+		return 0, 0, err
+	}
+	columns := 0
+	for _, row := range rows {
+		if len(row) > columns {
+			columns = len(row)
+		}
+	}
+	return len(rows), columns, nil
+}
+
+// UnmarshalSource reads the sheet of the cell source into v like UnmarshalSheet
+// Excelize files are read with FileSource by UnmarshalSheet with all features,
+// other sources don't support UnmarshalOptions.VerifyLocked, VerifySchema and type rows
+func UnmarshalSource(src CellSource, sheetName string, v interface{}, opts ...Option) error {
+	if fs, ok := src.(FileSource); ok {
+		return UnmarshalSheet(fs.File, sheetName, v, opts...)
+	}
+	if fs, ok := src.(*FileSource); ok {
+		return UnmarshalSheet(fs.File, sheetName, v, opts...)
+	}
+
+	o := newOptions(opts)
+
+	rv := reflect.ValueOf(v)
+	if err := checkDestination(rv); err != nil {
+		return err
+	}
+
+	rows, columns, err := src.Dimensions(sheetName)
+	if err != nil {
+		return err
+	}
+	r := &rowReader{source: src, sheetName: sheetName, rows: rows, columns: columns}
+
+	if isMapElem(rv.Elem().Type().Elem()) {
+		return unmarshalMaps(nil, r, sheetName, rv.Elem(), o.read.withDefaults())
+	}
+	return unmarshalTyped(nil, r, sheetName, rv.Elem(), o.read.withDefaults())
+}
+
+// sourceRow reads raw and formatted values and types of the current row cell by cell
+func (r *rowReader) sourceRow() ([]string, []string, error) {
+	raw := make([]string, r.columns)
+	formatted := make([]string, r.columns)
+	r.types = make([]excelize.CellType, r.columns)
+	for columnIdx := 0; columnIdx < r.columns; columnIdx++ {
+		cell := GetCellName(columnIdx, r.rowIdx)
+
+		var err error
+		raw[columnIdx], err = r.source.GetCellValue(r.sheetName, cell, excelize.Options{RawCellValue: true})
+		if err != nil {
+			return nil, nil, err
+		}
+		formatted[columnIdx], err = r.source.GetCellValue(r.sheetName, cell)
+		if err != nil {
+			return nil, nil, err
+		}
+		r.types[columnIdx], err = r.source.GetCellType(r.sheetName, cell)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return raw, formatted, nil
+}
+
+// cellType returns the type of the current row cell reported by the source or guessed by its values
+func (r *rowReader) cellType(columnIdx int, raw string, formatted string) excelize.CellType {
+	if columnIdx < len(r.types) && r.types[columnIdx] != excelize.CellTypeUnset {
+		return r.types[columnIdx]
+	}
+	return guessCellType(raw, formatted)
+}
+
+// MemorySource is the in-memory CellSource, e.g. for tests of Unmarshaler implementations
+// Cells are keyed by sheet and cell name
+type MemorySource struct {
+	// Raw are raw cell values
+	Raw map[string]map[string]string
+	// Formatted are formatted cell values, raw values are used for missing ones
+	Formatted map[string]map[string]string
+	// Types are cell types, missing ones are guessed
+	Types map[string]map[string]excelize.CellType
+}
+
+// NewMemorySource returns the source with the sheet of raw values, rows[0] is the row 1
+func NewMemorySource(sheetName string, rows [][]string) *MemorySource {
+	s := &MemorySource{}
+	s.SetRows(sheetName, rows)
+	return s
+}
+
+// SetRows replaces raw values of the sheet, rows[0] is the row 1
+func (s *MemorySource) SetRows(sheetName string, rows [][]string) {
+	if s.Raw == nil {
+		s.Raw = map[string]map[string]string{}
+	}
+	cells := map[string]string{}
+	for rowi, row := range rows {
+		for columni, value := range row {
+			if value != "" {
+				cells[GetCellName(columni, rowi+1)] = value
+			}
+		}
+	}
+	s.Raw[sheetName] = cells
+}
+
+func (s *MemorySource) GetCellValue(sheet string, cell string, opts ...excelize.Options) (string, error) {
+	cells, ok := s.Raw[sheet]
+	if !ok {
+		return "", fmt.Errorf("sheet %s does not exist", sheet)
+	}
+	if len(opts) == 0 || !opts[0].RawCellValue {
+		if formatted, ok := s.Formatted[sheet][cell]; ok {
+			return formatted, nil
+		}
+	}
+	return cells[cell], nil
+}
+
+func (s *MemorySource) GetCellType(sheet string, cell string) (excelize.CellType, error) {
+	if _, ok := s.Raw[sheet]; !ok {
+		return excelize.CellTypeUnset, fmt.Errorf("sheet %s does not exist", sheet)
+	}
+	return s.Types[sheet][cell], nil
+}
+
+func (s *MemorySource) Dimensions(sheet string) (int, int, error) {
+	cells, ok := s.Raw[sheet]
+	if !ok {
+		return 0, 0, fmt.Errorf("sheet %s does not exist", sheet)
+	}
+	rows, columns := 0, 0
+	for cell := range cells {
+		columnIdx, rowIdx, err := ParseCellName(cell)
+		if err != nil {
+			return 0, 0, err
+		}
+		if rowIdx > rows {
+			rows = rowIdx
+		}
+		if columnIdx+1 > columns {
+			columns = columnIdx + 1
+		}
+	}
+	return rows, columns, nil
+}
@@ -0,0 +1,147 @@
+package xlsx
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// WriteStream adds new sheet with data like Write, but rows are written with excelize StreamWriter
+// It keeps memory usage low for big exports
+// Only field hooks, progress, the date system, styles, the frozen header and the layout callback options are supported,
+// the sheet can't be changed by excelize cell functions until the stream is flushed
+// hyperlink and mergeVertical tags aren't supported, big numbers and decimal fields are rounded to float64 by StreamWriter,
+// use Write to keep all their digits
+func WriteStream(file *excelize.File, sheetName string, data interface{}, opts ...Option) error {
+	o := newOptions(opts)
+
+	if reflect.TypeOf(data).Kind() != reflect.Slice {
+		return fmt.Errorf("slice only is allowed")
+	}
+
+	slice := reflect.ValueOf(data)
+	if slice.Type().Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("slice of structs only is allowed")
+	}
+	columns := appendRestColumns(getColumns(slice.Type().Elem()), slice)
+	if err := checkColumns(columns); err != nil {
+		return err
+	}
+
+	if err := setDateSystem(file, o.write); err != nil {
+		return err
+	}
+	if err := setFullCalcOnLoad(file, o.write); err != nil {
+		return err
+	}
+
+	file.DeleteSheet(sheetName)
+	file.NewSheet(sheetName)
+	file.DeleteSheet("Sheet1")
+
+	styles, err := newSheetStyles(file, columns, o.write)
+	if err != nil {
+		return err
+	}
+
+	sw, err := file.NewStreamWriter(sheetName)
+	if err != nil {
+		return err
+	}
+
+	if slice.Len() > 0 && len(columns) > 0 {
+		width := lastColumnIndex(columns) + 1
+
+		// Panes must be set before rows
+		if o.write.FreezeHeader {
+			if err := sw.SetPanes(freezePanes(2)); err != nil {
+				return err
+			}
+		}
+
+		// Column widths must be set before rows
+		for _, c := range columns {
+			columnWidth := getColumnWidth(c.field)
+			if columnWidth != nil {
+				if err := sw.SetColWidth(c.index+1, c.index+1, *columnWidth); err != nil {
+					return err
+				}
+			}
+		}
+
+		// Set column names
+		header := make([]interface{}, width)
+		for _, c := range columns {
+			header[c.index] = excelize.Cell{StyleID: styles.header, Value: c.name}
+		}
+		if err := sw.SetRow("A1", header, excelize.RowOpts{Height: 18}); err != nil {
+			return err
+		}
+
+		// Set rows
+		p := newProgress(o.write.OnProgress, slice.Len())
+		for rowi := 0; rowi < slice.Len(); rowi++ {
+			element := slice.Index(rowi)
+			rowCtx := WriteContext{
+				File:      file,
+				SheetName: sheetName,
+				Index:     rowi,
+				Element:   element.Interface(),
+				RowIdx:    rowi + 2,
+			}
+
+			row := make([]interface{}, width)
+			for _, c := range columns {
+				cellValue, err := c.cellValue(element)
+				if err != nil {
+					return fmt.Errorf("field %s: %w", c.field.Name, err)
+				}
+
+				if o.write.OnField != nil {
+					ctx := rowCtx
+					ctx.ColumnIdx = c.index
+					ctx.Cell = GetCellName(c.index, rowi+2)
+					ctx.Field = c.field
+					ctx.Value = cellValue
+					if err := o.write.OnField(&ctx); err != nil {
+						return err
+					}
+					cellValue = ctx.Value
+				}
+				if n, ok := cellValue.(json.Number); ok {
+					// StreamWriter writes numbers of Go types only
+					if f, err := n.Float64(); err == nil {
+						cellValue = f
+					}
+				}
+
+				formula, err := c.formula(columns, rowi+2)
+				if err != nil {
+					return err
+				}
+				if formula != "" {
+					// Results are calculated on load, the field value isn't a result
+					cellValue = nil
+				}
+				row[c.index] = excelize.Cell{StyleID: styles.column(c.index), Value: cellValue, Formula: formula}
+			}
+
+			err := sw.SetRow(GetCellName(0, rowi+2), row, excelize.RowOpts{Height: 18})
+			if err != nil {
+				return err
+			}
+			p.add()
+		}
+	}
+
+	if err := sw.Flush(); err != nil {
+		return err
+	}
+
+	if o.write.OnLayout != nil {
+		o.write.OnLayout(newLayout(sheetName, columns, 1, 0, 2, slice.Len()))
+	}
+	return nil
+}
@@ -0,0 +1,248 @@
+package xlsx
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Style is the look of written cells, zero fields keep the default look
+type Style struct {
+	// Font is the font family, "Helvetica Neue" by default
+	Font string
+	// Size is the font size, 10 by default
+	Size float64
+	// Color is the font color like "#1F4E79", black by default
+	Color  string
+	Bold   bool
+	Italic bool
+	// Fill is the background color, no fill if empty
+	Fill string
+	// Border is the color of thin borders around cells, no borders if empty
+	Border string
+	// NumFmt is the custom number format like "#,##0.00"
+	NumFmt string
+
+	// underline underlines link cells
+	underline bool
+}
+
+// WithHeaderStyle sets the style of header cells
+func WithHeaderStyle(style Style) Option {
+	return func(o *options) {
+		o.write.HeaderStyle = style
+	}
+}
+
+// WithBodyStyle sets the style of data cells, totals and summary rows use its font
+func WithBodyStyle(style Style) Option {
+	return func(o *options) {
+		o.write.BodyStyle = style
+	}
+}
+
+// WithNamedStyles sets styles of data cells of fields with the style tag, e.g. `xlsx:"style:money"`
+// Zero fields of named styles are taken from the body style
+func WithNamedStyles(styles map[string]Style) Option {
+	return func(o *options) {
+		o.write.NamedStyles = styles
+	}
+}
+
+// WithForcedText writes string fields as text cells with the text number format,
+// Excel keeps numbers like IDs "00123" as they are and doesn't convert them, typed ones included
+func WithForcedText() Option {
+	return func(o *options) {
+		o.write.ForcedText = true
+	}
+}
+
+// isStringField reports whether the field is a string or a pointer to it
+func isStringField(field reflect.StructField) bool {
+	t := field.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.String
+}
+
+// merge returns the style with zero fields taken from base
+func (s Style) merge(base Style) Style {
+	if s.Font == "" {
+		s.Font = base.Font
+	}
+	if s.Size == 0 {
+		s.Size = base.Size
+	}
+	if s.Color == "" {
+		s.Color = base.Color
+	}
+	s.Bold = s.Bold || base.Bold
+	s.Italic = s.Italic || base.Italic
+	if s.Fill == "" {
+		s.Fill = base.Fill
+	}
+	if s.Border == "" {
+		s.Border = base.Border
+	}
+	if s.NumFmt == "" {
+		s.NumFmt = base.NumFmt
+	}
+	return s
+}
+
+// font returns the font of the style over the default font
+func (s Style) font() excelize.Font {
+	font := defaultFont
+	if s.Font != "" {
+		font.Family = s.Font
+	}
+	if s.Size != 0 {
+		font.Size = s.Size
+	}
+	if s.Color != "" {
+		font.Color = s.Color
+	}
+	font.Bold = s.Bold
+	font.Italic = s.Italic
+	if s.underline {
+		font.Underline = "single"
+	}
+	return font
+}
+
+// newStyle adds the style to the file, unlocked cells can be changed in protected sheets
+func (s Style) newStyle(file *excelize.File, unlocked bool) (int, error) {
+	font := s.font()
+	style := &excelize.Style{Font: &font}
+	if s.Fill != "" {
+		style.Fill = excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{s.Fill}}
+	}
+	if s.Border != "" {
+		for _, side := range []string{"left", "top", "right", "bottom"} {
+			style.Border = append(style.Border, excelize.Border{Type: side, Color: s.Border, Style: 1})
+		}
+	}
+	if s.NumFmt != "" {
+		numFmt := s.NumFmt
+		style.CustomNumFmt = &numFmt
+	}
+	if unlocked {
+		style.Protection = &excelize.Protection{Locked: false}
+	}
+	return file.NewStyle(style)
+}
+
+// sheetStyles are style IDs of the written sheet
+type sheetStyles struct {
+	header int
+	body   int
+	// columns are styles of columns with the style tag by column index
+	columns map[int]int
+	// inputs are styles of columns without the locked tag in protected sheets by column index
+	inputs map[int]int
+}
+
+// newSheetStyles adds styles of the header and data columns to the file
+func newSheetStyles(file *excelize.File, columns []column, o WriteOptions) (*sheetStyles, error) {
+	var err error
+	s := &sheetStyles{columns: map[int]int{}, inputs: map[int]int{}}
+	if s.header, err = o.HeaderStyle.merge(o.BodyStyle).newStyle(file, false); err != nil {
+		return nil, err
+	}
+	if s.body, err = o.BodyStyle.newStyle(file, false); err != nil {
+		return nil, err
+	}
+
+	protected := len(lockedColumns(columns)) > 0
+	for _, c := range columns {
+		style, custom, err := o.columnStyle(c)
+		if err != nil {
+			return nil, err
+		}
+		if custom {
+			if s.columns[c.index], err = style.newStyle(file, false); err != nil {
+				return nil, err
+			}
+		}
+		if protected && !getTagBool(c.field, "locked") {
+			if s.inputs[c.index], err = style.newStyle(file, true); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return s, nil
+}
+
+// columnStyle returns the style of data cells of the column, false if it's the body style
+func (o WriteOptions) columnStyle(c column) (Style, bool, error) {
+	style := o.BodyStyle
+	name, dateFormat := getTag(c.field, "style"), getTag(c.field, "datefmt")
+	if name != "" {
+		named, ok := o.NamedStyles[name]
+		if !ok {
+			return Style{}, false, fmt.Errorf("field %s: unknown style %q", c.field.Name, name)
+		}
+		style = named.merge(o.BodyStyle)
+	}
+	duration := isDurationField(c.field)
+	if duration && style.NumFmt == "" {
+		style.NumFmt = durationFormat
+	}
+	if dateFormat != "" {
+		style.NumFmt = dateFormat
+	}
+	forcedText := o.ForcedText && isStringField(c.field)
+	if forcedText {
+		// The text format keeps numbers typed later as text too
+		style.NumFmt = "@"
+	}
+	if len(c.units) > 0 {
+		style.NumFmt = withUnit(style.NumFmt, c.units[0])
+	}
+	hyperlink := isHyperlinkField(c.field)
+	if hyperlink {
+		if style.Color == "" {
+			style.Color = linkColor
+		}
+		style.underline = true
+	}
+	negative := getTag(c.field, "negative")
+	if negative != "" {
+		var err error
+		if style.NumFmt, err = withNegative(style.NumFmt, negative); err != nil {
+			return Style{}, false, fmt.Errorf("field %s: %w", c.field.Name, err)
+		}
+	}
+	custom := name != "" || dateFormat != "" || duration || hyperlink || negative != "" || forcedText || len(c.units) > 0
+	return style, custom, nil
+}
+
+// withNegative replaces the negative section of the number format, General if it's empty
+// negative is parens for accounting (1,234.00), red for red -1,234.00 or redparens for both
+func withNegative(numFmt string, negative string) (string, error) {
+	if numFmt == "" {
+		numFmt = "General"
+	}
+	positive := strings.SplitN(numFmt, ";", 2)[0]
+	switch negative {
+	case "parens":
+		// The space of the closing parenthesis aligns positive numbers with negative ones
+		return positive + "_);(" + positive + ")", nil
+	case "red":
+		return positive + ";[Red]-" + positive, nil
+	case "redparens":
+		return positive + "_);[Red](" + positive + ")", nil
+	}
+	return "", fmt.Errorf("unknown negative format %q", negative)
+}
+
+// column returns the style of data cells of the column
+func (s *sheetStyles) column(columnIdx int) int {
+	if style, ok := s.columns[columnIdx]; ok {
+		return style
+	}
+	return s.body
+}
@@ -0,0 +1,141 @@
+package xlsx
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// minSuggestionScore is the lowest score of suggested fields
+const minSuggestionScore = 0.4
+
+// HeaderSuggestion lists fields the sheet header may be read into, the best first
+type HeaderSuggestion struct {
+	Header  string
+	Matches []FieldMatch
+}
+
+// FieldMatch is the field suggested for the header
+type FieldMatch struct {
+	// Field is the field name like "Email" or "Audit.CreatedAt", see WithHeaderMapping
+	Field string
+	// Column is the column name of the field, the name tag or the field name
+	Column string
+	// Score is the similarity of the header and the column name or its alias, 1 if they are equal
+	// ignoring case, spaces and punctuation
+	Score float64
+}
+
+// SuggestMapping returns fields of the struct v like Contact{} similar to sheet headers in header order,
+// e.g. for the "map your columns" screen before Unmarshal with WithHeaderMapping
+func SuggestMapping(headers []string, v interface{}) ([]HeaderSuggestion, error) {
+	t, err := structType(v)
+	if err != nil {
+		return nil, err
+	}
+	columns := readColumns(t, "")
+
+	suggestions := make([]HeaderSuggestion, 0, len(headers))
+	for _, header := range headers {
+		s := HeaderSuggestion{Header: header}
+		key := similarityKey(header)
+		for _, c := range columns {
+			best := similarity(key, similarityKey(c.field.Name))
+			for _, alias := range c.aliases {
+				if score := similarity(key, similarityKey(alias)); score > best {
+					best = score
+				}
+			}
+			if best >= minSuggestionScore {
+				s.Matches = append(s.Matches, FieldMatch{Field: c.field.Name, Column: c.name, Score: best})
+			}
+		}
+		sort.SliceStable(s.Matches, func(i, j int) bool {
+			return s.Matches[i].Score > s.Matches[j].Score
+		})
+		suggestions = append(suggestions, s)
+	}
+	return suggestions, nil
+}
+
+// WithHeaderMapping reads sheet headers into fields, e.g. chosen by users, keys are headers, values are field names
+// like "Email" or "Audit.CreatedAt", other fields are matched by their names
+func WithHeaderMapping(mapping map[string]string) Option {
+	return func(o *options) {
+		o.read.HeaderMapping = mapping
+	}
+}
+
+// structType returns the struct type of the value or the pointer
+func structType(v interface{}) (reflect.Type, error) {
+	t := reflect.TypeOf(v)
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("struct only is allowed")
+	}
+	return t, nil
+}
+
+// similarityKey returns lower case letters and digits of the name
+func similarityKey(name string) []rune {
+	var key []rune
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			key = append(key, r)
+		}
+	}
+	return key
+}
+
+// similarity returns 1 minus the edit distance divided by the longer length,
+// names containing the other one like "Full name" and "Name" score at least 0.6
+func similarity(a []rune, b []rune) float64 {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	if len(a) == 0 {
+		return 0
+	}
+	score := 1 - float64(levenshtein(a, b))/float64(len(a))
+	if len(b) > 0 && strings.Contains(string(a), string(b)) {
+		if contained := 0.6 + 0.4*float64(len(b))/float64(len(a)); contained > score {
+			score = contained
+		}
+	}
+	return score
+}
+
+// levenshtein returns the number of inserted, deleted and replaced letters turning a into b
+func levenshtein(a []rune, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min3(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
@@ -0,0 +1,125 @@
+package xlsx
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// maxExcelInteger is the largest integer Excel keeps all digits of
+const maxExcelInteger = 999999999999999
+
+// WriteTemplateHeaders adds the sheet with the header of the struct columns and no data,
+// e.g. the blank upload template for customers, v is the struct like Product{} or a pointer to it
+// Columns get widths, header comments of the desc tag and styles of data cells for typed values,
+// numbers, booleans and dates with the datefmt tag are checked by data validations
+// Values of example tags like example:12.50 are written below the header in gray, Unmarshal skips that row
+// Banner, header styles, named styles and frozen header options are supported
+func WriteTemplateHeaders(file *excelize.File, sheetName string, v interface{}, opts ...Option) error {
+	o := newOptions(opts)
+
+	t, err := structType(v)
+	if err != nil {
+		return err
+	}
+	columns := readColumns(t, "")
+	if err := checkColumns(columns); err != nil {
+		return err
+	}
+
+	if err := setDateSystem(file, o.write); err != nil {
+		return err
+	}
+	file.DeleteSheet(sheetName)
+	file.NewSheet(sheetName)
+	file.DeleteSheet("Sheet1")
+
+	styles, err := newSheetStyles(file, columns, o.write)
+	if err != nil {
+		return err
+	}
+
+	headerRow := o.write.headerRow()
+	if o.write.Banner != "" {
+		if err := writeBanner(file, sheetName, columns, o.write.Banner); err != nil {
+			return err
+		}
+	}
+	for _, c := range columns {
+		// Column styles format values typed in the rows below the header
+		letter := getColumnLetter(c.index)
+		if err := file.SetColStyle(sheetName, letter, styles.column(c.index)); err != nil {
+			return err
+		}
+		dv, ok := typeValidation(c.field)
+		if !ok {
+			continue
+		}
+		dv.Sqref = fmt.Sprintf("%s%d:%s%d", letter, headerRow+1, letter, excelize.TotalRows)
+		if err := file.AddDataValidation(sheetName, dv); err != nil {
+			return err
+		}
+	}
+	// Column styles replace styles of cells, so the header goes after them
+	if err := writeHeader(file, sheetName, columns, headerRow, styles); err != nil {
+		return err
+	}
+	if err := writeExampleRow(file, sheetName, columns, headerRow, o.write); err != nil {
+		return err
+	}
+
+	if o.write.FreezeHeader {
+		if err := file.SetPanes(sheetName, freezePanes(headerRow+1)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// typeValidation returns the data validation of values of the field type, false if any text is accepted
+func typeValidation(field reflect.StructField) (*excelize.DataValidation, bool) {
+	t := field.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	dv := excelize.NewDataValidation(true)
+
+	var err error
+	switch {
+	case t == reflect.TypeOf(time.Time{}) && getTag(field, "datefmt") != "":
+		// Serials of 1900-01-01 and 9999-12-31
+		err = dv.SetRange(1, 2958465, excelize.DataValidationTypeDate, excelize.DataValidationOperatorBetween)
+		dv.SetError(excelize.DataValidationErrorStyleStop, field.Name, "Enter a date")
+	case t == durationType, t.Kind() == reflect.Struct:
+		return nil, false
+	case reflect.PtrTo(t).Implements(marshalerType), reflect.PtrTo(t).Implements(textMarshalerType),
+		reflect.PtrTo(t).Implements(valuerType):
+		return nil, false
+	case t.Kind() == reflect.Bool:
+		err = dv.SetDropList([]string{"TRUE", "FALSE"})
+		dv.SetError(excelize.DataValidationErrorStyleStop, field.Name, "Choose TRUE or FALSE")
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Int64:
+		min, max := -maxExcelInteger, maxExcelInteger
+		if bits := t.Bits(); bits < 64 {
+			min, max = -1<<(bits-1), 1<<(bits-1)-1
+		}
+		err = dv.SetRange(min, max, excelize.DataValidationTypeWhole, excelize.DataValidationOperatorBetween)
+		dv.SetError(excelize.DataValidationErrorStyleStop, field.Name, "Enter a whole number")
+	case t.Kind() >= reflect.Uint && t.Kind() <= reflect.Uint64:
+		max := maxExcelInteger
+		if bits := t.Bits(); bits < 64 {
+			max = 1<<bits - 1
+		}
+		err = dv.SetRange(0, max, excelize.DataValidationTypeWhole, excelize.DataValidationOperatorBetween)
+		dv.SetError(excelize.DataValidationErrorStyleStop, field.Name, "Enter a whole number not below zero")
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		err = dv.SetRange(-math.MaxFloat32, math.MaxFloat32, excelize.DataValidationTypeDecimal, excelize.DataValidationOperatorBetween)
+		dv.SetError(excelize.DataValidationErrorStyleStop, field.Name, "Enter a number")
+	default:
+		return nil, false
+	}
+	return dv, err == nil
+}
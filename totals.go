@@ -0,0 +1,71 @@
+package xlsx
+
+import (
+	"fmt"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// WithTotals adds a row of aggregations over all data rows right below the header
+// totals maps field names to aggregations: sum, count, avg, min or max, the total tag does the same, e.g. `xlsx:"total:sum"`
+// The header and the totals stay visible while scrolling, summary rows of groups are not counted twice
+// The totals row is marked in a hidden column, so Unmarshal skips it
+func WithTotals(totals map[string]string) Option {
+	return func(o *options) {
+		if totals == nil {
+			totals = map[string]string{}
+		}
+		o.write.Totals = totals
+	}
+}
+
+// hasTotals reports whether the totals row is written
+func hasTotals(columns []column, o WriteOptions) bool {
+	if o.Totals != nil {
+		return true
+	}
+	for _, c := range columns {
+		if getTag(c.field, "total") != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// writeTotals writes SUBTOTAL formulas over data rows from dataFrom to dataTo and freezes rows above dataFrom
+func writeTotals(file *excelize.File, sheetName string, columns []column, rowIdx int, dataFrom int, dataTo int, o WriteOptions) error {
+	file.SetRowHeight(sheetName, rowIdx, 18)
+
+	font := o.BodyStyle.font()
+	font.Bold = true
+	style, _ := file.NewStyle(&excelize.Style{
+		Font:   &font,
+		Fill:   excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"#F2F2F2"}},
+		Border: []excelize.Border{{Type: "bottom", Color: "#000000", Style: 1}},
+	})
+
+	for _, c := range columns {
+		cell := GetCellName(c.index, rowIdx)
+		file.SetCellStyle(sheetName, cell, cell, style)
+
+		aggregate, ok := o.Totals[c.field.Name]
+		if !ok {
+			aggregate = getTag(c.field, "total")
+		}
+		if aggregate == "" || dataTo < dataFrom {
+			continue
+		}
+
+		function, ok := subtotalFunctions[aggregate]
+		if !ok {
+			return fmt.Errorf("field %s: unknown aggregation %q", c.field.Name, aggregate)
+		}
+		from, to := GetCellName(c.index, dataFrom), GetCellName(c.index, dataTo)
+		formula := fmt.Sprintf("SUBTOTAL(%d,%s:%s)", function, from, to)
+		if err := file.SetCellFormula(sheetName, cell, formula); err != nil {
+			return err
+		}
+	}
+
+	return file.SetPanes(sheetName, freezePanes(dataFrom))
+}
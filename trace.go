@@ -0,0 +1,113 @@
+package xlsx
+
+import (
+	"database/sql"
+	"encoding"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Coercion is the way the cell is converted to the field, see WithCoercionTrace
+type Coercion struct {
+	// Cell is the cell name like "C5"
+	Cell  string
+	Field string
+	// Raw is the stored value, Formatted is the value as it is shown
+	Raw       string
+	Formatted string
+	// Path is the conversion, see coercionPath
+	Path string
+}
+
+// WithCoercionTrace records conversions of all read cells in ImportReport.Coercions, e.g. to find out
+// why the cell is read as it is, the report is set with WithImportReport
+// Traces are long, it's meant for debugging
+func WithCoercionTrace() Option {
+	return func(o *options) {
+		o.read.TraceCoercion = true
+	}
+}
+
+// traceCoercion records the conversion of the cell to the report
+func (d *cellDecoder) traceCoercion(c column, raw string, formatted string, path string) {
+	if !d.o.TraceCoercion || d.o.Report == nil {
+		return
+	}
+	d.o.Report.Coercions = append(d.o.Report.Coercions, Coercion{
+		Cell:      GetCellName(c.index, d.rows.rowIdx),
+		Field:     c.field.Name,
+		Raw:       raw,
+		Formatted: formatted,
+		Path:      path,
+	})
+}
+
+// coercionPath returns the path convertCell takes for the cell converted to the type:
+// unmarshaler, date-serial, date-text, duration-serial, duration-text, big-number, scanner, text-unmarshaler,
+// text, bool, raw-int, raw-float, formatted-int, formatted-float and comma-decimal if the shown number has commas,
+// paths of nullable structs like sql.NullInt64 have the null- prefix
+func coercionPath(t reflect.Type, raw string, formatted string, ctype excelize.CellType) string {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	ptr := reflect.PtrTo(t)
+	isText := ctype == excelize.CellTypeSharedString || ctype == excelize.CellTypeInlineString
+	_, rawErr := strconv.ParseFloat(raw, 64)
+
+	switch {
+	case ptr.Implements(reflect.TypeOf((*Unmarshaler)(nil)).Elem()):
+		return "unmarshaler"
+	case t == reflect.TypeOf(time.Time{}):
+		if !isText && rawErr == nil {
+			return "date-serial"
+		}
+		return "date-text"
+	case t == durationType:
+		if !isText && rawErr == nil {
+			return "duration-serial"
+		}
+		return "duration-text"
+	case t == bigIntType || t == bigFloatType || t == bigRatType:
+		return "big-number"
+	}
+	if inner, _, ok := nullFields(reflect.New(t).Elem()); ok {
+		return "null-" + coercionPath(inner.Type(), raw, formatted, ctype)
+	}
+	switch {
+	case ptr.Implements(reflect.TypeOf((*sql.Scanner)(nil)).Elem()):
+		return "scanner"
+	case ptr.Implements(reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()):
+		return "text-unmarshaler"
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return "text"
+	case reflect.Bool:
+		return "bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if _, ok := toIntegerDecimalString(raw); ok {
+			return "raw-int"
+		}
+		return formattedNumberPath(formatted, "formatted-int")
+	case reflect.Float32, reflect.Float64:
+		if rawErr == nil {
+			return "raw-float"
+		}
+		return formattedNumberPath(formatted, "formatted-float")
+	}
+	return t.String()
+}
+
+// formattedNumberPath returns comma-decimal if parseFloat handles commas of the shown number
+func formattedNumberPath(formatted string, path string) string {
+	if strings.Contains(formatted, ",") {
+		return "comma-decimal"
+	}
+	return path
+}
@@ -0,0 +1,56 @@
+package xlsx
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// WithTransforms sets transforms of fields by field name like "Phone" or "Contact.Phone", e.g. to normalize phones
+// Unmarshal passes the cell text to the transform and sets the result to the field, texts are converted like cells,
+// Write passes the text of the cell value and writes the result instead
+func WithTransforms(transforms map[string]func(string) (interface{}, error)) Option {
+	return func(o *options) {
+		o.read.Transforms = transforms
+		o.write.Transforms = transforms
+	}
+}
+
+// transformCell sets the transformed cell text to the field, nil results set zero values
+func transformCell(value reflect.Value, transform func(string) (interface{}, error), formatted string, date1904 bool) error {
+	result, err := transform(formatted)
+	if err != nil {
+		return err
+	}
+	if s, ok := result.(string); ok {
+		return convertCell(value, s, s, excelize.CellTypeSharedString, date1904)
+	}
+	if result == nil {
+		value.Set(reflect.Zero(value.Type()))
+		return nil
+	}
+	v := reflect.ValueOf(result)
+	switch {
+	case v.Type().AssignableTo(value.Type()):
+		value.Set(v)
+	case v.Type().ConvertibleTo(value.Type()):
+		value.Set(v.Convert(value.Type()))
+	default:
+		return fmt.Errorf("transform returned %s, not %s", v.Type(), value.Type())
+	}
+	return nil
+}
+
+// transformValue returns the written value of the field transformed, the value itself without the transform
+func (o WriteOptions) transformValue(field reflect.StructField, value interface{}) (interface{}, error) {
+	transform, ok := o.Transforms[field.Name]
+	if !ok {
+		return value, nil
+	}
+	result, err := transform(fmt.Sprint(value))
+	if err != nil {
+		return nil, fmt.Errorf("field %s: %w", field.Name, err)
+	}
+	return result, nil
+}
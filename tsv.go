@@ -0,0 +1,63 @@
+package xlsx
+
+import (
+	"io"
+	"strings"
+)
+
+// tsvSheet is the sheet name of pasted data in errors
+const tsvSheet = "TSV"
+
+// UnmarshalTSV reads tab-separated values like data copied from Excel into v like Unmarshal,
+// e.g. for "paste from Excel" imports
+// Values are the text shown in cells, cells with tabs or line breaks are quoted like Excel does it
+// The text is UTF-8, UTF-16 or Windows-1251, see WithCharset
+func UnmarshalTSV(r io.Reader, v interface{}, opts ...Option) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	text, err := decodeText(data, newOptions(opts).read.Charset)
+	if err != nil {
+		return err
+	}
+	return UnmarshalSource(NewMemorySource(tsvSheet, parseTSV(text)), tsvSheet, v, opts...)
+}
+
+// parseTSV splits the text into rows of cells, empty lines are kept as empty rows
+func parseTSV(data string) [][]string {
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	data = strings.TrimSuffix(data, "\n")
+	if data == "" {
+		return nil
+	}
+
+	var rows [][]string
+	var row []string
+	var cell strings.Builder
+	inQuotes := false
+	for i := 0; i < len(data); i++ {
+		ch := data[i]
+		switch {
+		case inQuotes && ch == '"' && i+1 < len(data) && data[i+1] == '"':
+			cell.WriteByte('"')
+			i++
+		case inQuotes && ch == '"':
+			inQuotes = false
+		case inQuotes:
+			cell.WriteByte(ch)
+		case ch == '"' && cell.Len() == 0:
+			inQuotes = true
+		case ch == '\t':
+			row = append(row, cell.String())
+			cell.Reset()
+		case ch == '\n':
+			rows = append(rows, append(row, cell.String()))
+			row = nil
+			cell.Reset()
+		default:
+			cell.WriteByte(ch)
+		}
+	}
+	return append(rows, append(row, cell.String()))
+}
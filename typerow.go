@@ -0,0 +1,151 @@
+package xlsx
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// WithTypeRow adds a hidden row below the header with type hints of columns like "int64|" or
+// "time.Time|2006-01-02 15:04:05", Unmarshal finds it and converts cells by hints instead of guessing
+func WithTypeRow() Option {
+	return func(o *options) {
+		o.write.TypeRow = true
+	}
+}
+
+// typeHint is the type of column cells and their format
+type typeHint struct {
+	kind   string
+	format string
+}
+
+// String returns the hint as it is written, e.g. "int64|#,##0"
+func (h typeHint) String() string {
+	return h.kind + "|" + h.format
+}
+
+// parseTypeHint parses the written hint, it returns false if the text isn't a hint
+func parseTypeHint(s string) (typeHint, bool) {
+	parts := strings.SplitN(s, "|", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return typeHint{}, false
+	}
+	return typeHint{kind: parts[0], format: parts[1]}, true
+}
+
+// columnTypeHint returns the hint of values written by getCellValue
+func columnTypeHint(field reflect.StructField) typeHint {
+	t := field.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if reflect.PtrTo(t).Implements(marshalerType) || reflect.PtrTo(t).Implements(valuerType) {
+		return typeHint{kind: "any"}
+	}
+	if t != reflect.TypeOf(time.Time{}) && reflect.PtrTo(t).Implements(textMarshalerType) {
+		return typeHint{kind: "string"}
+	}
+	if t == reflect.TypeOf(time.Time{}) {
+		// Times with the datefmt tag are serial numbers
+		if getTag(field, "datefmt") != "" {
+			return typeHint{kind: "time.Time"}
+		}
+		return typeHint{kind: "time.Time", format: "2006-01-02 15:04:05"}
+	}
+	return typeHint{kind: t.Kind().String()}
+}
+
+// typeHint returns the hint of the column, values of map elements can be of any type
+func (c column) typeHint() typeHint {
+	switch {
+	case c.restKey != "" && len(c.field.Index) == 0:
+		return typeHint{kind: "any"}
+	case c.restKey != "":
+		return typeHint{kind: "string"}
+	}
+	return columnTypeHint(c.field)
+}
+
+// cellType returns the cell type of the hint kind, false if the cell type should be guessed
+func (h typeHint) cellType() (excelize.CellType, bool) {
+	if h.kind == "time.Time" && h.format == "" {
+		// Times without a layout are serial numbers
+		return excelize.CellTypeNumber, true
+	}
+	switch h.kind {
+	case "string", "time.Time":
+		return excelize.CellTypeSharedString, true
+	case "bool":
+		return excelize.CellTypeBool, true
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64",
+		"float32", "float64":
+		return excelize.CellTypeNumber, true
+	}
+	return excelize.CellTypeUnset, false
+}
+
+// writeTypeRow writes hints of all columns to the hidden row
+func writeTypeRow(file *excelize.File, sheetName string, columns []column, rowIdx int) error {
+	for _, c := range columns {
+		hint := c.typeHint()
+		err := file.SetCellStr(sheetName, GetCellName(c.index, rowIdx), hint.String())
+		if err != nil {
+			return err
+		}
+	}
+	return file.SetRowVisible(sheetName, rowIdx, false)
+}
+
+// parseTypeRow returns hints by column index if the row is a hidden type row
+func parseTypeRow(file *excelize.File, sheetName string, rowIdx int, row []string) (map[int]typeHint, bool) {
+	if file == nil {
+		return nil, false
+	}
+	if visible, err := file.GetRowVisible(sheetName, rowIdx); err != nil || visible {
+		return nil, false
+	}
+
+	hints := map[int]typeHint{}
+	for columnIdx, s := range row {
+		if s == "" {
+			continue
+		}
+		hint, ok := parseTypeHint(s)
+		if !ok {
+			return nil, false
+		}
+		hints[columnIdx] = hint
+	}
+	return hints, len(hints) > 0
+}
+
+// convertHinted sets time fields by the time layout of the hint, it returns false if the field is not set
+func convertHinted(value reflect.Value, hint typeHint, formatted string) bool {
+	if hint.kind != "time.Time" || hint.format == "" {
+		return false
+	}
+
+	ptr := value.Kind() == reflect.Ptr
+	t := value.Type()
+	if ptr {
+		t = t.Elem()
+	}
+	if t != reflect.TypeOf(time.Time{}) {
+		return false
+	}
+
+	parsed, err := time.Parse(hint.format, strings.TrimSpace(formatted))
+	if err != nil {
+		return false
+	}
+	if ptr {
+		value.Set(reflect.ValueOf(&parsed))
+	} else {
+		value.Set(reflect.ValueOf(parsed))
+	}
+	return true
+}
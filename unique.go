@@ -0,0 +1,97 @@
+package xlsx
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// WithUniqueBy makes Unmarshal return DuplicateRowsError if rows repeat the combination of fields like "Email", "Phone",
+// rows are read anyway, so duplicates can be just shown to users, rows with all these fields zero aren't checked
+// Fields are field names, nested fields are named like "Contact.Email"
+func WithUniqueBy(fields ...string) Option {
+	return func(o *options) {
+		o.read.UniqueBy = fields
+	}
+}
+
+// DuplicateRow is the row repeating fields of the earlier row
+type DuplicateRow struct {
+	// Row and FirstRow are row indexes of the duplicate and of the first row with the fields
+	Row      int
+	FirstRow int
+	// Values are cells of the fields as they are read
+	Values []string
+}
+
+// DuplicateRowsError lists rows repeating fields of WithUniqueBy
+type DuplicateRowsError struct {
+	SheetName  string
+	Fields     []string
+	Duplicates []DuplicateRow
+}
+
+func (e *DuplicateRowsError) Error() string {
+	rows := make([]string, len(e.Duplicates))
+	for i, d := range e.Duplicates {
+		rows[i] = fmt.Sprintf("%d and %d (%s)", d.FirstRow, d.Row, strings.Join(d.Values, ", "))
+	}
+	return fmt.Sprintf("sheet %s: duplicate %s in rows %s", e.SheetName, strings.Join(e.Fields, ", "), strings.Join(rows, "; "))
+}
+
+// uniqueRows finds elements repeating fields of WithUniqueBy
+type uniqueRows struct {
+	columns []column
+	// rows maps keys of fields to the first rows with them
+	rows       map[string]int
+	duplicates []DuplicateRow
+}
+
+// newUniqueRows returns the checker of the fields of the struct type, nil without fields
+func newUniqueRows(elemType reflect.Type, fields []string, version string) (*uniqueRows, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	u := &uniqueRows{rows: map[string]int{}}
+	for _, name := range fields {
+		found := false
+		for _, c := range readColumns(elemType, version) {
+			if c.field.Name == name {
+				u.columns = append(u.columns, c)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%s has no field %s", elemType, name)
+		}
+	}
+	return u, nil
+}
+
+// add records the element of the row, duplicates of earlier rows are collected
+func (u *uniqueRows) add(element reflect.Value, rowIdx int) {
+	values := make([]string, len(u.columns))
+	empty := true
+	for i, c := range u.columns {
+		value := element.FieldByIndex(c.field.Index)
+		if !value.IsZero() {
+			empty = false
+		}
+		values[i] = fmt.Sprint(value.Interface())
+	}
+	if empty {
+		return
+	}
+
+	var key strings.Builder
+	for _, value := range values {
+		key.WriteString(strconv.Quote(value))
+	}
+	if first, ok := u.rows[key.String()]; ok {
+		u.duplicates = append(u.duplicates, DuplicateRow{Row: rowIdx, FirstRow: first, Values: values})
+		return
+	}
+	u.rows[key.String()] = rowIdx
+}
@@ -0,0 +1,48 @@
+package xlsx
+
+import (
+	"reflect"
+	"strings"
+)
+
+// getUnits returns units of the unit tag separated by "|", e.g. unit:kg|кг
+func getUnits(field reflect.StructField) []string {
+	units := getTag(field, "unit")
+	if units == "" {
+		return nil
+	}
+	return strings.Split(units, "|")
+}
+
+// withUnit appends the unit after a space to every section of the number format, General if it's empty
+func withUnit(numFmt string, unit string) string {
+	if numFmt == "" {
+		numFmt = "General"
+	}
+	// Every character is escaped, so units like % aren't format codes
+	var suffix strings.Builder
+	suffix.WriteString(`\ `)
+	for _, r := range unit {
+		suffix.WriteRune('\\')
+		suffix.WriteRune(r)
+	}
+
+	sections := strings.Split(numFmt, ";")
+	for i, section := range sections {
+		if section != "" {
+			sections[i] = section + suffix.String()
+		}
+	}
+	return strings.Join(sections, ";")
+}
+
+// trimUnit removes the first matching unit at the end of the value with spaces around it
+func trimUnit(value string, units []string) string {
+	trimmed := strings.TrimSpace(value)
+	for _, unit := range units {
+		if unit != "" && strings.HasSuffix(trimmed, unit) {
+			return strings.TrimSpace(strings.TrimSuffix(trimmed, unit))
+		}
+	}
+	return value
+}
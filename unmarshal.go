@@ -0,0 +1,1138 @@
+package xlsx
+
+import (
+	"database/sql"
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/xuri/excelize/v2"
+	textencoding "golang.org/x/text/encoding"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	defaultMaxColumns     = 1000
+	defaultEmptyHeaderGap = 10
+	defaultEmptyRowGap    = 10
+)
+
+// Unmarshaler is implemented by types which parse cells themselves
+// raw is the unformatted value, formatted is the value as it is shown in Excel
+type Unmarshaler interface {
+	UnmarshalXLSXCell(raw string, formatted string, ctype excelize.CellType) error
+}
+
+var timeLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	time.RFC3339,
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"02.01.2006 15:04:05",
+	"02.01.2006 15:04",
+	"02.01.2006",
+	"01/02/2006 15:04:05",
+	"01/02/2006 15:04",
+	"01/02/2006",
+	"01-02-06",
+	"1/2/06 15:04",
+	"1/2/06",
+}
+
+// Unmarshal reads the first sheet of the file into v, v must be a pointer to a slice of structs,
+// of map[string]string or of map[string]interface{} keyed by header, see isMapElem
+// The first row is the header unless UnmarshalOptions say otherwise,
+// columns are matched with fields by the name tag (aliases in order) or the field name, fields with col or index tags are read from their columns
+// Fields of nested structs are matched by the dotted name like "Audit.CreatedAt", fields of embedded structs by their own names
+// Rows with all mapped cells empty are skipped
+// Fields of types implementing Unmarshaler parse cells themselves, encoding.TextUnmarshaler ones parse the cell text
+// and sql.Scanner ones scan it, nullable structs like sql.NullTime are converted like their values and become valid
+// time.Duration fields are read from elapsed time serials, texts like "1:30:00" and Go durations like "90m"
+// Fields with hyperlink tags get link targets of cells, see cellDecoder.hyperlink
+// Number cells are parsed by TextUnmarshaler from raw digits, big.Int, big.Float and big.Rat fields get them without float64 rounding
+// Columns of fields with the required tag must be in the header, otherwise MissingColumnsError is returned
+// Fields of empty cells get values of the default tag like default:0, default:UA or default:now of times
+// Fields breaking validation tags like min and enum are CellError, all are collected with CollectErrors, see checkRules
+// Tags scoped to template versions like name[v2] replace their tags with WithTemplateVersion
+// Cells are converted by hints of the hidden type row written with WithTypeRow instead of guessing their types
+// The map[string]string field with the rest tag gets cells of other columns by header
+// A slice field with the children tag gets detail rows following its row, see childrenField
+func Unmarshal(file *excelize.File, v interface{}, opts ...Option) error {
+	return UnmarshalSheet(file, file.GetSheetName(0), v, opts...)
+}
+
+// UnmarshalSheet reads the sheet with the given name into v, see Unmarshal
+func UnmarshalSheet(file *excelize.File, sheetName string, v interface{}, opts ...Option) error {
+	o := newOptions(opts)
+
+	if index, err := file.GetSheetIndex(sheetName); err != nil || index < 0 {
+		return fmt.Errorf("sheet %q not found", sheetName)
+	}
+
+	rv := reflect.ValueOf(v)
+	if err := checkDestination(rv); err != nil {
+		return err
+	}
+
+	rows, err := o.read.newRowReader(file, sheetName)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	if isMapElem(rv.Elem().Type().Elem()) {
+		return unmarshalMaps(file, rows, sheetName, rv.Elem(), o.read.withDefaults())
+	}
+	return unmarshalTyped(file, rows, sheetName, rv.Elem(), o.read.withDefaults())
+}
+
+// checkDestination returns an error if Unmarshal can't read into the value
+func checkDestination(rv reflect.Value) error {
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("pointer to slice is required")
+	}
+
+	elemType := rv.Elem().Type().Elem()
+	if isMapElem(elemType) {
+		return nil
+	}
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return fmt.Errorf("slice of structs only is allowed")
+	}
+	return nil
+}
+
+// UnmarshalSheetIndex reads the sheet with the given zero based index into v, see Unmarshal
+func UnmarshalSheetIndex(file *excelize.File, index int, v interface{}, opts ...Option) error {
+	sheetName := file.GetSheetName(index)
+	if sheetName == "" {
+		return fmt.Errorf("sheet with index %d not found", index)
+	}
+	return UnmarshalSheet(file, sheetName, v, opts...)
+}
+
+// HeaderMatcher reports whether the sheet header is the column name of the name tag or the field name
+// Headers are tried in column order, the first match wins
+type HeaderMatcher func(header string, name string) bool
+
+// WithHeaderMatcher sets the matcher of headers which aren't found by their names
+func WithHeaderMatcher(matcher HeaderMatcher) Option {
+	return func(o *options) {
+		o.read.HeaderMatcher = matcher
+	}
+}
+
+// UnmarshalOptions configures Unmarshal
+// Zero fields are replaced with defaults
+type UnmarshalOptions struct {
+	// HeaderRow is the row with column names, 1 by default
+	HeaderRow int
+	// DataStartRow is the first data row, the row after the header by default
+	// It can't be above the header row
+	DataStartRow int
+	// MaxColumns is the number of header cells scanned at most, 1000 by default
+	MaxColumns int
+	// EmptyRowGap stops reading after that many empty rows in a row, 10 by default
+	EmptyRowGap int
+	// EmptyHeaderGap stops the header scan after that many empty header cells in a row, 10 by default
+	EmptyHeaderGap int
+	// CollectErrors makes Unmarshal return CellErrors with all cells which can't be converted,
+	// otherwise such cells leave zero values
+	CollectErrors bool
+	// Strict makes Unmarshal stop with CellError at the first cell which can't be converted
+	// Together with CollectErrors all such cells are collected
+	Strict bool
+	// NoHeader means the sheet has no header row, only fields with col or index tags are read
+	// HeaderRow is ignored and DataStartRow is 1 by default
+	NoHeader bool
+	// Provenance records source rows of read elements, see WithProvenance
+	Provenance *Provenance
+	// NormalizeHeaders matches headers ignoring case, leading, trailing and repeated spaces
+	NormalizeHeaders bool
+	// StripHeaderPunctuation matches headers like NormalizeHeaders ignoring punctuation too, "e-mail" is "Email"
+	StripHeaderPunctuation bool
+	// HeaderMatcher matches headers which differ from column names, e.g. with typos
+	HeaderMatcher HeaderMatcher
+	// HeaderMapping maps headers to field names, see WithHeaderMapping
+	HeaderMapping map[string]string
+	// FuzzyHeaders is the number of typos of headers still matched with column names, see WithFuzzyHeaders
+	FuzzyHeaders int
+	// Report gets details of the import, see WithImportReport
+	Report *ImportReport
+	// Charset is the encoding of TSV text, see WithCharset
+	Charset textencoding.Encoding
+	// VerifySchema returns SchemaError if headers or field types differ from the schema saved by WithSchema
+	VerifySchema bool
+	// VerifyLocked compares cells of columns with the locked tag with checksums written by Write
+	// Rows are read anyway, changed rows are returned in TamperedRowsError
+	VerifyLocked bool
+	// OnProgress is called after every sheet row is read, rowsTotal is the number of sheet rows, see WithProgress
+	OnProgress func(rowsDone, rowsTotal int)
+	// ScrubStrings removes invisible characters and surrounding spaces of cells, see WithScrubStrings
+	ScrubStrings bool
+	// NormalizeUnicode converts cells and headers to the NFC form, see WithNormalizeUnicode
+	NormalizeUnicode bool
+	// FoldHeaders matches headers like NormalizeHeaders with full case folding
+	// and Cyrillic letters looking like Latin ones, see WithFoldHeaders
+	FoldHeaders bool
+	// CalcFormulas calculates formula cells instead of reading saved results, see WithCalcFormulas
+	CalcFormulas bool
+	// MergedCells reads values of vertically merged cells in all covered rows, see WithMergedCells
+	MergedCells bool
+	// RowValidator checks decoded rows of structs, see WithRowValidator
+	RowValidator func(ctx *RowContext) error
+	// Transforms convert cell texts of fields by field name, see WithTransforms
+	Transforms map[string]func(string) (interface{}, error)
+	// Cache keeps rows of read sheets for later reads, see WithSheetCache
+	Cache *SheetCache
+	// TraceCoercion records conversions of cells in the report, see WithCoercionTrace
+	TraceCoercion bool
+	// UniqueBy are fields whose combination can't repeat in rows of structs, see WithUniqueBy
+	UniqueBy []string
+	// TemplateVersion selects tags scoped to the template version, see WithTemplateVersion
+	TemplateVersion string
+
+	// flat ignores the children tag
+	flat bool
+}
+
+func (o UnmarshalOptions) withDefaults() UnmarshalOptions {
+	if o.NoHeader {
+		o.HeaderRow = 0
+	} else if o.HeaderRow < 1 {
+		o.HeaderRow = 1
+	}
+	if o.DataStartRow <= o.HeaderRow {
+		o.DataStartRow = o.HeaderRow + 1
+	}
+	if o.MaxColumns < 1 {
+		o.MaxColumns = defaultMaxColumns
+	}
+	if o.EmptyRowGap < 1 {
+		o.EmptyRowGap = defaultEmptyRowGap
+	}
+	if o.EmptyHeaderGap < 1 {
+		o.EmptyHeaderGap = defaultEmptyHeaderGap
+	}
+	return o
+}
+
+// prepareRows sets up the row reader of the file by options, file is nil for other CellSources
+func (o UnmarshalOptions) prepareRows(rows *rowReader, file *excelize.File) error {
+	rows.clean = o.cellCleaner()
+	if file == nil {
+		return nil
+	}
+	if o.CalcFormulas {
+		rows.calc = file
+	}
+	if o.MergedCells {
+		return rows.loadMerged(file)
+	}
+	return nil
+}
+
+// unmarshalSheetTyped appends rows of the file sheet to the slice of structs
+func unmarshalSheetTyped(file *excelize.File, sheetName string, slice reflect.Value, o UnmarshalOptions) error {
+	rows, err := o.newRowReader(file, sheetName)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	return unmarshalTyped(file, rows, sheetName, slice, o)
+}
+
+// unmarshalTyped appends rows of the sheet to the slice of structs
+// file is nil if rows are read from another CellSource, features depending on excelize are off then
+func unmarshalTyped(file *excelize.File, rows *rowReader, sheetName string, slice reflect.Value, o UnmarshalOptions) error {
+	if file == nil && (o.VerifyLocked || o.VerifySchema) {
+		return fmt.Errorf("locked cells and schemas are verified in excelize files only")
+	}
+	if err := o.prepareRows(rows, file); err != nil {
+		return err
+	}
+	elemType := slice.Type().Elem()
+	isPtr := elemType.Kind() == reflect.Ptr
+	if isPtr {
+		elemType = elemType.Elem()
+	}
+	children, childType, nested := childrenField(elemType)
+	nested = nested && !o.flat
+
+	var h *header
+	var mapped, childMapped, keys []column
+	headerFound := false
+
+	var locked []column
+	var tampered []int
+	checksumIdx := -1
+
+	d := &cellDecoder{rows: rows, file: file, sheetName: sheetName, o: o, date1904: isDate1904(file)}
+	p, err := newReadProgress(file, sheetName, rows, o.OnProgress)
+	if err != nil {
+		return err
+	}
+
+	unique, err := newUniqueRows(elemType, o.UniqueBy, o.TemplateVersion)
+	if err != nil {
+		return err
+	}
+
+	appendElement := func(element reflect.Value, rowIdx int) {
+		if unique != nil {
+			unique.add(element, rowIdx)
+		}
+		if o.Provenance != nil {
+			o.Provenance.SourceSheet = sheetName
+			o.Provenance.Rows = append(o.Provenance.Rows, rowIdx)
+		}
+
+		if isPtr {
+			slice.Set(reflect.Append(slice, element.Addr()))
+		} else {
+			slice.Set(reflect.Append(slice, element))
+		}
+	}
+
+	// parent is the element detail rows are appended to, it is appended to the slice when the next parent starts
+	var parent reflect.Value
+	var parentRow int
+	var parentKey []string
+
+	if o.NoHeader {
+		headerFound = true
+		mapped = mapColumns(elemType, nil, o.TemplateVersion)
+		if nested {
+			childMapped = mapColumns(childType, nil, o.TemplateVersion)
+			keys = keyColumns(mapped)
+		}
+		if len(mapped) == 0 && len(childMapped) == 0 {
+			return nil
+		}
+	}
+	emptyRows := 0
+	for rows.Next() && emptyRows < o.EmptyRowGap {
+		p.set(rows.rowIdx)
+		if rows.rowIdx < o.HeaderRow || (rows.rowIdx > o.HeaderRow && rows.rowIdx < o.DataStartRow) {
+			continue
+		}
+
+		raw, formatted, err := rows.Row()
+		if err != nil {
+			return err
+		}
+
+		if rows.rowIdx == o.HeaderRow {
+			h = parseHeader(formatted, o)
+			if err := checkRequired(sheetName, elemType, h, o.TemplateVersion); err != nil {
+				return err
+			}
+			headerFound = true
+
+			mapped = mapColumns(elemType, h, o.TemplateVersion)
+			if nested {
+				if err := checkRequired(sheetName, childType, h, o.TemplateVersion); err != nil {
+					return err
+				}
+				childMapped = mapColumns(childType, h, o.TemplateVersion)
+				keys = keyColumns(mapped)
+			}
+			mapped = append(mapped, mapRestColumns(elemType, h, mapped, childMapped)...)
+
+			if o.VerifySchema {
+				if err := verifySchema(file, sheetName, formatted, mapped); err != nil {
+					return err
+				}
+			}
+			if len(mapped) == 0 && len(childMapped) == 0 {
+				return nil
+			}
+
+			if o.VerifyLocked {
+				locked = lockedColumns(mapped)
+				idx, ok := h.find(checksumHeader)
+				if len(locked) > 0 && !ok {
+					return fmt.Errorf("sheet %s: no checksums of locked columns", sheetName)
+				}
+				checksumIdx = idx
+			}
+			continue
+		}
+
+		if rows.rowIdx == o.HeaderRow+1 && !o.NoHeader {
+			if hints, ok := parseTypeRow(file, sheetName, rows.rowIdx, raw); ok {
+				d.hints = hints
+				continue
+			}
+		}
+		kind := h.rowKind(raw)
+		if kind == footerRowKind {
+			break
+		}
+		if h.isExample(raw) || kind != "" {
+			continue
+		}
+
+		element := reflect.New(elemType).Elem()
+		empty, err := d.decode(element, mapped, raw, formatted)
+		if err != nil {
+			return err
+		}
+
+		var child reflect.Value
+		childEmpty := true
+		if nested {
+			child = reflect.New(childType).Elem()
+			childEmpty, err = d.decode(child, childMapped, raw, formatted)
+			if err != nil {
+				return err
+			}
+		}
+
+		if empty && childEmpty {
+			emptyRows++
+			continue
+		}
+		emptyRows = 0
+		if !empty {
+			if err := d.validate(element, mapped, raw, formatted); err != nil {
+				return err
+			}
+		}
+
+		if len(locked) > 0 {
+			checksum, err := rowChecksum(file, sheetName, locked, rows.rowIdx)
+			if err != nil {
+				return err
+			}
+			if checksum != cellAt(raw, checksumIdx) {
+				tampered = append(tampered, rows.rowIdx)
+			}
+		}
+
+		if !nested {
+			appendElement(element, rows.rowIdx)
+			continue
+		}
+
+		// A row starts a new parent if its key differs or, without key columns, if any parent cell is filled
+		key := rowKey(keys, raw)
+		startsParent := !empty
+		if len(keys) > 0 {
+			startsParent = key != nil && !equalKeys(key, parentKey)
+		}
+		if startsParent || !parent.IsValid() {
+			if parent.IsValid() {
+				appendElement(parent, parentRow)
+			}
+			parent, parentRow, parentKey = element, rows.rowIdx, key
+		}
+		if !childEmpty {
+			appendChild(parent.FieldByIndex(children.Index), child)
+		}
+	}
+	if parent.IsValid() {
+		appendElement(parent, parentRow)
+	}
+
+	if !headerFound {
+		if err := checkRequired(sheetName, elemType, nil, o.TemplateVersion); err != nil {
+			return err
+		}
+	}
+
+	p.finish()
+
+	if len(d.errors) > 0 {
+		return d.errors
+	}
+	if len(tampered) > 0 {
+		return &TamperedRowsError{SheetName: sheetName, Rows: tampered}
+	}
+	if unique != nil && len(unique.duplicates) > 0 {
+		return &DuplicateRowsError{SheetName: sheetName, Fields: o.UniqueBy, Duplicates: unique.duplicates}
+	}
+	return nil
+}
+
+// cellDecoder converts cells of sheet rows to struct fields
+type cellDecoder struct {
+	rows *rowReader
+	// file is nil if rows are read from another CellSource
+	file      *excelize.File
+	sheetName string
+	o         UnmarshalOptions
+	date1904  bool
+	// hints are found in the hidden type row below the header, see WithTypeRow
+	hints map[int]typeHint
+	// errors are collected with UnmarshalOptions.CollectErrors
+	errors CellErrors
+}
+
+// decode converts cells of the row to fields of the element and reports whether all cells are empty
+func (d *cellDecoder) decode(element reflect.Value, columns []column, raw []string, formatted []string) (bool, error) {
+	empty := true
+	for _, c := range columns {
+		rawValue := cellAt(raw, c.index)
+		if rawValue == "" {
+			if c.restKey == "" {
+				if err := setDefault(element.FieldByIndex(c.field.Index), c); err != nil {
+					return empty, err
+				}
+			}
+			continue
+		}
+		empty = false
+
+		formattedValue := cellAt(formatted, c.index)
+		if len(c.units) > 0 {
+			rawValue, formattedValue = trimUnit(rawValue, c.units), trimUnit(formattedValue, c.units)
+		}
+		if c.restKey != "" {
+			setRestValue(element.FieldByIndex(c.field.Index), c.restKey, formattedValue)
+			continue
+		}
+
+		ctype := d.rows.cellType(c.index, rawValue, formattedValue)
+		hint, hinted := d.hints[c.index]
+		if t, ok := hint.cellType(); hinted && ok {
+			ctype = t
+		}
+		if (ctype == excelize.CellTypeSharedString || ctype == excelize.CellTypeInlineString) &&
+			strings.HasPrefix(rawValue, "'") && d.quotePrefixed(c.index) {
+			rawValue, formattedValue = trimApostrophe(rawValue), trimApostrophe(formattedValue)
+		}
+		if d.file != nil && isHyperlinkField(c.field) {
+			target, ok, err := d.hyperlink(element, c, formattedValue)
+			if err != nil {
+				return empty, err
+			}
+			if ok {
+				rawValue, formattedValue, ctype = target, target, excelize.CellTypeSharedString
+			}
+		}
+		if hinted && convertHinted(element.FieldByIndex(c.field.Index), hint, formattedValue) {
+			d.traceCoercion(c, rawValue, formattedValue, "hinted")
+			continue
+		}
+		var err error
+		if transform, ok := d.o.Transforms[c.field.Name]; ok {
+			err = transformCell(element.FieldByIndex(c.field.Index), transform, formattedValue, d.date1904)
+			if err == nil {
+				d.traceCoercion(c, rawValue, formattedValue, "transform")
+			}
+		} else if field := element.FieldByIndex(c.field.Index); isSeparatedField(c.field, field) {
+			err = splitSeparated(field, getTag(c.field, "sep"), formattedValue, d.date1904)
+			if err == nil {
+				d.traceCoercion(c, rawValue, formattedValue, "separated")
+			}
+		} else {
+			err = convertCell(element.FieldByIndex(c.field.Index), rawValue, formattedValue, ctype, d.date1904)
+			if err == nil {
+				d.traceCoercion(c, rawValue, formattedValue, coercionPath(c.field.Type, rawValue, formattedValue, ctype))
+			}
+		}
+		if err != nil && (d.o.CollectErrors || d.o.Strict) {
+			cellErr := &CellError{
+				SheetName: d.sheetName,
+				Cell:      GetCellName(c.index, d.rows.rowIdx),
+				Header:    c.name,
+				Field:     c.field.Name,
+				Raw:       rawValue,
+				Err:       err,
+			}
+			if !d.o.CollectErrors {
+				return empty, cellErr
+			}
+			d.errors = append(d.errors, cellErr)
+		}
+	}
+	return empty, nil
+}
+
+// nullFields returns the value and the Valid fields of nullable structs like sql.NullString
+func nullFields(value reflect.Value) (reflect.Value, reflect.Value, bool) {
+	t := value.Type()
+	if t.Kind() != reflect.Struct || t.NumField() != 2 || !reflect.PtrTo(t).Implements(scannerType) {
+		return reflect.Value{}, reflect.Value{}, false
+	}
+	if valid := t.Field(1); valid.Name != "Valid" || valid.Type.Kind() != reflect.Bool || !t.Field(0).IsExported() {
+		return reflect.Value{}, reflect.Value{}, false
+	}
+	return value.Field(0), value.Field(1), true
+}
+
+// trimApostrophe removes the leading apostrophe of text typed as forced text, e.g. '00123
+func trimApostrophe(s string) string {
+	return strings.TrimPrefix(s, "'")
+}
+
+// quotePrefixed reports whether the style of the current row cell marks text typed with a leading apostrophe
+// Other apostrophes like in '80s are text
+func (d *cellDecoder) quotePrefixed(columnIdx int) bool {
+	if d.file == nil {
+		return false
+	}
+	styleID, err := d.file.GetCellStyle(d.sheetName, GetCellName(columnIdx, d.rows.rowIdx))
+	if err != nil || d.file.Styles == nil || d.file.Styles.CellXfs == nil || styleID >= len(d.file.Styles.CellXfs.Xf) {
+		return false
+	}
+	quotePrefix := d.file.Styles.CellXfs.Xf[styleID].QuotePrefix
+	return quotePrefix != nil && *quotePrefix
+}
+
+// mapColumns returns struct fields found in the header with indexes of their sheet columns
+// Fields with col or index tags are bound to their columns whatever the header is
+func mapColumns(elemType reflect.Type, h *header, version string) []column {
+	var mapped []column
+	for _, c := range readColumns(elemType, version) {
+		if _, ok := getColumnIndex(c.field); ok {
+			mapped = append(mapped, c)
+		} else if columnIdx, name, ok := h.findColumn(c); ok {
+			c.index, c.name = columnIdx, name
+			mapped = append(mapped, c)
+		}
+	}
+	return mapped
+}
+
+// checkRequired returns MissingColumnsError if the header lacks columns of fields with the required tag
+func checkRequired(sheetName string, elemType reflect.Type, h *header, version string) error {
+	var missing []string
+	for _, c := range readColumns(elemType, version) {
+		if _, ok := getColumnIndex(c.field); ok || !getTagBool(c.field, "required") {
+			continue
+		}
+		if _, _, ok := h.findColumn(c); !ok {
+			missing = append(missing, c.name)
+		}
+	}
+	if len(missing) > 0 {
+		return &MissingColumnsError{SheetName: sheetName, Columns: missing}
+	}
+	return nil
+}
+
+// header is the parsed header row
+type header struct {
+	// indexes maps header keys to the first columns with them
+	indexes map[string]int
+	key     func(string) string
+	// names are header names in column order
+	names   []headerName
+	matcher HeaderMatcher
+	// mapped are headers of fields by field name, see UnmarshalOptions.HeaderMapping
+	mapped map[string]string
+	// example is the index of the column marking the example row, -1 if there is none
+	example int
+	// maxDistance is the number of typos of headers matched with WithFuzzyHeaders
+	maxDistance int
+	report      *ImportReport
+}
+
+type headerName struct {
+	name      string
+	columnIdx int
+}
+
+// parseHeader reads header names of the row
+func parseHeader(row []string, o UnmarshalOptions) *header {
+	h := &header{indexes: map[string]int{}, key: o.headerKey, matcher: o.HeaderMatcher, example: -1,
+		maxDistance: o.FuzzyHeaders, report: o.Report}
+	if len(o.HeaderMapping) > 0 {
+		h.mapped = make(map[string]string, len(o.HeaderMapping))
+		for header, field := range o.HeaderMapping {
+			h.mapped[field] = header
+		}
+	}
+	emptyCells := 0
+	for columnIdx := 0; columnIdx < o.MaxColumns && emptyCells < o.EmptyHeaderGap; columnIdx++ {
+		name := strings.TrimSpace(cellAt(row, columnIdx))
+		if name == "" {
+			emptyCells++
+			continue
+		}
+		emptyCells = 0
+
+		if name == exampleMarker {
+			h.example = columnIdx
+		}
+		h.names = append(h.names, headerName{name: name, columnIdx: columnIdx})
+		if _, ok := h.indexes[h.key(name)]; !ok {
+			h.indexes[h.key(name)] = columnIdx
+		}
+	}
+	return h
+}
+
+// find returns the column index of the header name, nothing is found in the nil header
+func (h *header) find(name string) (int, bool) {
+	if h == nil {
+		return 0, false
+	}
+	columnIdx, ok := h.indexes[h.key(name)]
+	return columnIdx, ok
+}
+
+// findColumn returns the column index and the first alias of the column found in the header
+// The header mapped to the field is the only one tried
+// Headers with typos are tried before HeaderMatcher if no alias is found as is, see WithFuzzyHeaders
+func (h *header) findColumn(c column) (int, string, bool) {
+	if h != nil && h.mapped[c.field.Name] != "" {
+		name := h.mapped[c.field.Name]
+		columnIdx, ok := h.find(name)
+		return columnIdx, name, ok
+	}
+	for _, alias := range c.aliases {
+		if columnIdx, ok := h.find(alias); ok {
+			return columnIdx, alias, true
+		}
+	}
+
+	if h == nil {
+		return 0, "", false
+	}
+	if h.maxDistance > 0 {
+		if hn, ok := h.findFuzzy(c); ok {
+			return hn.columnIdx, hn.name, true
+		}
+	}
+	if h.matcher == nil {
+		return 0, "", false
+	}
+	for _, alias := range c.aliases {
+		for _, hn := range h.names {
+			if h.matcher(hn.name, alias) {
+				return hn.columnIdx, hn.name, true
+			}
+		}
+	}
+	return 0, "", false
+}
+
+// headerKey returns the header name as it is compared with column names
+func (o UnmarshalOptions) headerKey(name string) string {
+	name = strings.TrimSpace(name)
+	if o.NormalizeUnicode || o.FoldHeaders {
+		name = norm.NFC.String(name)
+	}
+	if !o.NormalizeHeaders && !o.StripHeaderPunctuation && !o.FoldHeaders {
+		return name
+	}
+
+	if o.StripHeaderPunctuation {
+		name = strings.Map(func(r rune) rune {
+			if unicode.IsPunct(r) || unicode.IsSymbol(r) {
+				return -1
+			}
+			return r
+		}, name)
+	}
+	name = strings.Join(strings.Fields(name), " ")
+	if o.FoldHeaders {
+		return foldHeader(name)
+	}
+	return strings.ToLower(name)
+}
+
+// rowReader streams rows of the sheet
+// It keeps two excelize iterators in step because one iterator returns either raw or formatted values,
+// rows of other cell sources are read cell by cell
+type rowReader struct {
+	raw       *excelize.Rows
+	formatted *excelize.Rows
+	// rowIdx is the index of the current row
+	rowIdx int
+
+	source    CellSource
+	sheetName string
+	rows      int
+	columns   int
+	// types are cell types of the current source row
+	types []excelize.CellType
+	// clean changes cells of returned rows, see UnmarshalOptions.cellCleaner
+	clean func(string) string
+	// calc is the file whose formula cells are calculated, see UnmarshalOptions.CalcFormulas
+	calc *excelize.File
+	// merged are values of merged ranges by covered rows, see UnmarshalOptions.MergedCells
+	merged map[int][]mergedCell
+	// cached are rows of the sheet read from SheetCache
+	cached *cachedSheet
+}
+
+func newRowReader(file *excelize.File, sheetName string) (*rowReader, error) {
+	raw, err := file.Rows(sheetName)
+	if err != nil {
+		return nil, err
+	}
+	formatted, err := file.Rows(sheetName)
+	if err != nil {
+		raw.Close()
+		return nil, err
+	}
+	return &rowReader{raw: raw, formatted: formatted, sheetName: sheetName}, nil
+}
+
+// Next moves to the next row, rows missing in the file are returned as empty
+func (r *rowReader) Next() bool {
+	if r.cached != nil {
+		if r.rowIdx >= len(r.cached.raw) {
+			return false
+		}
+		r.rowIdx++
+		return true
+	}
+	if r.source != nil {
+		if r.rowIdx >= r.rows {
+			return false
+		}
+		r.rowIdx++
+		return true
+	}
+	if !r.raw.Next() || !r.formatted.Next() {
+		return false
+	}
+	r.rowIdx++
+	return true
+}
+
+// Row returns raw and formatted values of the current row
+func (r *rowReader) Row() ([]string, []string, error) {
+	raw, formatted, err := r.row()
+	if err == nil && r.calc != nil {
+		err = r.calcFormulas(raw, formatted)
+	}
+	if err == nil && r.merged != nil {
+		raw, formatted = r.fillMerged(raw, formatted)
+	}
+	if err == nil && r.clean != nil {
+		cleanRow(raw, r.clean)
+		cleanRow(formatted, r.clean)
+	}
+	return raw, formatted, err
+}
+
+func (r *rowReader) row() ([]string, []string, error) {
+	if r.cached != nil {
+		raw, formatted := r.cachedRow()
+		return raw, formatted, nil
+	}
+	if r.source != nil {
+		return r.sourceRow()
+	}
+	raw, err := r.raw.Columns(excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, nil, err
+	}
+	formatted, err := r.formatted.Columns()
+	if err != nil {
+		return nil, nil, err
+	}
+	return raw, formatted, nil
+}
+
+func (r *rowReader) Close() error {
+	if r.source != nil || r.cached != nil {
+		return nil
+	}
+	err := r.raw.Close()
+	if ferr := r.formatted.Close(); err == nil {
+		err = ferr
+	}
+	return err
+}
+
+func cellAt(row []string, columnIdx int) string {
+	if columnIdx < len(row) {
+		return row[columnIdx]
+	}
+	return ""
+}
+
+// guessCellType returns the cell type by its values, row iterators don't report types
+func guessCellType(raw string, formatted string) excelize.CellType {
+	if _, err := strconv.ParseFloat(raw, 64); err != nil {
+		return excelize.CellTypeSharedString
+	}
+	if (raw == "1" || raw == "0") && (formatted == "TRUE" || formatted == "FALSE") {
+		return excelize.CellTypeBool
+	}
+	return excelize.CellTypeNumber
+}
+
+func isDate1904(file *excelize.File) bool {
+	if file == nil {
+		return false
+	}
+	props, err := file.GetWorkbookProps()
+	return err == nil && props.Date1904 != nil && *props.Date1904
+}
+
+// convertCell sets the cell value to the field
+// raw is the unformatted value, formatted is the value as it is shown in Excel
+// The field is not changed if the value can't be converted to the field type
+func convertCell(value reflect.Value, raw string, formatted string, ctype excelize.CellType, date1904 bool) error {
+	if value.Kind() == reflect.Ptr {
+		ptr := reflect.New(value.Type().Elem())
+		if err := convertCell(ptr.Elem(), raw, formatted, ctype, date1904); err != nil {
+			return err
+		}
+		value.Set(ptr)
+		return nil
+	}
+
+	if value.CanAddr() {
+		if u, ok := value.Addr().Interface().(Unmarshaler); ok {
+			return u.UnmarshalXLSXCell(raw, formatted, ctype)
+		}
+	}
+
+	if value.Type() == reflect.TypeOf(time.Time{}) {
+		t, err := parseTime(raw, formatted, ctype, date1904)
+		if err != nil {
+			return err
+		}
+		value.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	if value.Type() == durationType {
+		d, err := parseDuration(raw, formatted, ctype)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(d))
+		return nil
+	}
+
+	if ok, err := convertBig(value, raw); ok {
+		return err
+	}
+
+	if inner, valid, ok := nullFields(value); ok {
+		// sql.NullString and alike get the converted value and become valid
+		if err := convertCell(inner, raw, formatted, ctype, date1904); err != nil {
+			return err
+		}
+		valid.SetBool(true)
+		return nil
+	}
+
+	if value.CanAddr() {
+		switch u := value.Addr().Interface().(type) {
+		case sql.Scanner:
+			if ctype == excelize.CellTypeNumber {
+				return u.Scan(raw)
+			}
+			return u.Scan(formatted)
+		case encoding.TextUnmarshaler:
+			// Numbers are parsed from their stored digits, decimals don't lose any to the number format
+			if ctype == excelize.CellTypeNumber {
+				return u.UnmarshalText([]byte(raw))
+			}
+			return u.UnmarshalText([]byte(formatted))
+		}
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(formatted)
+
+	case reflect.Bool:
+		b, err := parseBool(raw)
+		if err != nil {
+			return err
+		}
+		value.SetBool(b)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := parseInt(raw, formatted, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetInt(i)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := parseUint(raw, formatted, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetUint(u)
+
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, value.Type().Bits())
+		if err != nil {
+			f, err = parseFloat(formatted)
+			if err != nil {
+				return err
+			}
+		}
+		value.SetFloat(f)
+
+	default:
+		return fmt.Errorf("unsupported type %s", value.Type())
+	}
+	return nil
+}
+
+func parseBool(s string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "yes", "y":
+		return true, nil
+	case "no", "n":
+		return false, nil
+	}
+	return strconv.ParseBool(strings.TrimSpace(s))
+}
+
+func parseInt(raw string, formatted string, bitSize int) (int64, error) {
+	if s, ok := toIntegerDecimalString(raw); ok {
+		return strconv.ParseInt(s, 10, bitSize)
+	}
+	f, err := parseFloat(formatted)
+	if err != nil {
+		return 0, err
+	}
+	if f != float64(int64(f)) {
+		return 0, fmt.Errorf("%q is not an integer", formatted)
+	}
+	return strconv.ParseInt(strconv.FormatInt(int64(f), 10), 10, bitSize)
+}
+
+func parseUint(raw string, formatted string, bitSize int) (uint64, error) {
+	if s, ok := toIntegerDecimalString(raw); ok {
+		return strconv.ParseUint(s, 10, bitSize)
+	}
+	f, err := parseFloat(formatted)
+	if err != nil {
+		return 0, err
+	}
+	if f < 0 || f != float64(uint64(f)) {
+		return 0, fmt.Errorf("%q is not an unsigned integer", formatted)
+	}
+	return strconv.ParseUint(strconv.FormatUint(uint64(f), 10), 10, bitSize)
+}
+
+// maxDecimalExponent is the largest exponent of numbers converted by toIntegerDecimalString
+const maxDecimalExponent = 400
+
+// toIntegerDecimalString converts a number like "12", "12.0" or "1.2E+1" to "12"
+// without going through float64, so big integers keep all digits
+// It returns false if the number has a fractional part
+func toIntegerDecimalString(s string) (string, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return "", false
+	}
+
+	sign := ""
+	if s[0] == '-' || s[0] == '+' {
+		if s[0] == '-' {
+			sign = "-"
+		}
+		s = s[1:]
+	}
+
+	exp := 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.Atoi(s[i+1:])
+		// Exponents beyond float64 ones aren't cell values, they'd take gigabytes of zeros
+		if err != nil || e > maxDecimalExponent || e < -maxDecimalExponent {
+			return "", false
+		}
+		exp = e
+		s = s[:i]
+	}
+
+	intPart, fracPart := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, fracPart = s[:i], s[i+1:]
+	}
+	if intPart == "" && fracPart == "" {
+		return "", false
+	}
+	for _, r := range intPart + fracPart {
+		if r < '0' || r > '9' {
+			return "", false
+		}
+	}
+
+	digits := intPart + fracPart
+	point := len(intPart) + exp
+	if point < 0 {
+		point = 0
+		digits = strings.Repeat("0", -(len(intPart)+exp)) + digits
+	}
+	if point > len(digits) {
+		digits += strings.Repeat("0", point-len(digits))
+	}
+	if strings.Trim(digits[point:], "0") != "" {
+		return "", false
+	}
+
+	digits = strings.TrimLeft(digits[:point], "0")
+	if digits == "" {
+		return "0", true
+	}
+	return sign + digits, true
+}
+
+// parseFloat parses a number as it is shown in Excel
+// Spaces used as thousand separators are removed, a comma is accepted as the decimal separator
+func parseFloat(s string) (float64, error) {
+	s = strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\u00a0', '\u202f':
+			return -1
+		}
+		return r
+	}, s)
+
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f, nil
+	}
+
+	comma := strings.LastIndexByte(s, ',')
+	dot := strings.LastIndexByte(s, '.')
+	switch {
+	case comma >= 0 && dot > comma:
+		// 1,234.5
+		s = strings.ReplaceAll(s, ",", "")
+	case comma >= 0 && dot >= 0:
+		// 1.234,5
+		s = strings.ReplaceAll(s, ".", "")
+		s = strings.Replace(s, ",", ".", 1)
+	case comma >= 0 && strings.Count(s, ",") == 1:
+		// 1234,5
+		s = strings.Replace(s, ",", ".", 1)
+	case comma >= 0:
+		// 1,234,567
+		s = strings.ReplaceAll(s, ",", "")
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// parseTime converts a date serial number or a date string to time
+func parseTime(raw string, formatted string, ctype excelize.CellType, date1904 bool) (time.Time, error) {
+	if ctype != excelize.CellTypeSharedString && ctype != excelize.CellTypeInlineString {
+		if f, err := strconv.ParseFloat(raw, 64); err == nil {
+			return excelize.ExcelDateToTime(f, date1904)
+		}
+	}
+
+	s := strings.TrimSpace(formatted)
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("can't parse time %q", formatted)
+}
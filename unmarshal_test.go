@@ -0,0 +1,130 @@
+package xlsx
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func FuzzToIntegerDecimalString(f *testing.F) {
+	for _, s := range []string{"12", "12.0", "1.2E+1", "-0.5E1", "+7", "1e-3", "123456789012345678901234567890", ".5e1", "", "e5", "1.2.3"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		got, ok := toIntegerDecimalString(s)
+		if !ok {
+			return
+		}
+		if _, valid := new(big.Int).SetString(got, 10); !valid {
+			t.Fatalf("%q: result %q is not an integer", s, got)
+		}
+
+		// The result must be the exact value of the input
+		r, valid := new(big.Rat).SetString(strings.TrimPrefix(strings.TrimSpace(s), "+"))
+		if !valid {
+			return
+		}
+		if !r.IsInt() || r.Num().String() != got {
+			t.Fatalf("%q: got %q, want %s", s, got, r.RatString())
+		}
+	})
+}
+
+func FuzzParseFloat(f *testing.F) {
+	for _, s := range []string{"1,234.5", "1.234,5", "1234,5", "1,234,567", "1 234,5", "1 234", "-0.5", "1e3", "NaN", ",", ""} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		got, err := parseFloat(s)
+		if err != nil {
+			return
+		}
+
+		// Numbers Go can parse are parsed the same way
+		if want, err := strconv.ParseFloat(s, 64); err == nil && got != want && !(math.IsNaN(got) && math.IsNaN(want)) {
+			t.Fatalf("%q: got %v, want %v", s, got, want)
+		}
+	})
+}
+
+func FuzzParseTime(f *testing.F) {
+	f.Add("45000.5", "2023-03-15 12:00:00", false, false)
+	f.Add("", "15.03.2023", true, false)
+	f.Add("", "03/15/2023 12:00", true, true)
+	f.Add("-1", "", false, true)
+	f.Add("1e308", "", false, false)
+	f.Fuzz(func(t *testing.T, raw string, formatted string, isString bool, date1904 bool) {
+		ctype := excelize.CellTypeNumber
+		if isString {
+			ctype = excelize.CellTypeSharedString
+		}
+		got, err := parseTime(raw, formatted, ctype, date1904)
+		if err != nil {
+			return
+		}
+
+		// Numeric cells are serial numbers
+		if serial, err := strconv.ParseFloat(raw, 64); err == nil && !isString {
+			want, _ := excelize.ExcelDateToTime(serial, date1904)
+			if !got.Equal(want) {
+				t.Fatalf("%q: got %v, want %v", raw, got, want)
+			}
+		}
+	})
+}
+
+func TestApostropheText(t *testing.T) {
+	type row struct {
+		ID   string
+		Era  string
+		Code int
+	}
+	file := excelize.NewFile()
+	if err := Write(file, "Data", []row{{"00123", "'80s", 7}}, WithForcedText()); err != nil {
+		t.Fatal(err)
+	}
+
+	// A cell of another writer keeping the apostrophe of forced text
+	quoted, err := file.NewStyle(&excelize.Style{CustomNumFmt: stringPtr("@;@")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	quotePrefix := true
+	file.Styles.CellXfs.Xf[quoted].QuotePrefix = &quotePrefix
+	if err := file.SetCellStr("Data", "A3", "'00456"); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.SetCellStyle("Data", "A3", "A3", quoted); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []row
+	if err := UnmarshalSheet(reopen(t, file), "Data", &got); err != nil {
+		t.Fatal(err)
+	}
+	want := []row{{"00123", "'80s", 7}, {ID: "00456"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	idStyle, err := file.GetCellStyle("Data", "A2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	codeStyle, err := file.GetCellStyle("Data", "C2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if idStyle == codeStyle {
+		t.Fatal("forced text shares the style of numbers")
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,302 @@
+package xlsx
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// UpdateColumns rewrites cells of the columns of rows whose key cells equal key fields of data elements,
+// e.g. refreshes prices of a workbook maintained by hand
+// keyField and columns are field names like "Price" or "Audit.UpdatedAt", their sheet columns are found
+// by headers like Unmarshal does, the header is the first of top rows with the key, so banners are skipped
+// Other cells keep their values, formulas and styles, rows without elements and elements without rows are left as they are
+func UpdateColumns(file *excelize.File, sheetName string, data interface{}, keyField string, columns ...string) error {
+	slice := reflect.ValueOf(data)
+	if slice.Kind() != reflect.Slice {
+		return fmt.Errorf("slice only is allowed")
+	}
+	elemType := slice.Type().Elem()
+	if elemType.Kind() != reflect.Struct {
+		return fmt.Errorf("slice of structs only is allowed")
+	}
+	if index, err := file.GetSheetIndex(sheetName); err != nil || index < 0 {
+		return fmt.Errorf("sheet %q not found", sheetName)
+	}
+
+	key, ok := fieldColumn(elemType, keyField)
+	if !ok {
+		return fmt.Errorf("%s has no field %s", elemType, keyField)
+	}
+	updated := make([]column, 0, len(columns))
+	for _, name := range columns {
+		c, ok := fieldColumn(elemType, name)
+		if !ok {
+			return fmt.Errorf("%s has no field %s", elemType, name)
+		}
+		updated = append(updated, c)
+	}
+
+	// The first element with the key is written
+	elements, err := keyElements(slice, key)
+	if err != nil {
+		return err
+	}
+	matches, err := matchKeyRows(file, sheetName, key, updated, elements)
+	if err != nil {
+		return err
+	}
+	if len(matches.missing) > 0 {
+		return &MissingColumnsError{SheetName: sheetName, Columns: matches.missing}
+	}
+	for rowIdx, i := range matches.rows {
+		if err := updateRow(file, sheetName, slice.Index(i), rowIdx, matches.columns, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdateByKey rewrites cells of rows whose key cells equal key fields of data elements like UpdateColumns,
+// all fields found in the header are written, and appends rows of elements with new keys after the last row
+// of the data before the footer written by Write, appended cells get styles of the last row with the key
+// Columns of the sheet without fields and fields without columns are left as they are
+func UpdateByKey(file *excelize.File, sheetName string, keyField string, data interface{}) error {
+	slice := reflect.ValueOf(data)
+	if slice.Kind() != reflect.Slice {
+		return fmt.Errorf("slice only is allowed")
+	}
+	elemType := slice.Type().Elem()
+	if elemType.Kind() != reflect.Struct {
+		return fmt.Errorf("slice of structs only is allowed")
+	}
+	if index, err := file.GetSheetIndex(sheetName); err != nil || index < 0 {
+		return fmt.Errorf("sheet %q not found", sheetName)
+	}
+
+	key, ok := fieldColumn(elemType, keyField)
+	if !ok {
+		return fmt.Errorf("%s has no field %s", elemType, keyField)
+	}
+	var columns []column
+	for _, c := range getColumns(elemType) {
+		if c.field.Name != key.field.Name {
+			columns = append(columns, c)
+		}
+	}
+
+	elements, err := keyElements(slice, key)
+	if err != nil {
+		return err
+	}
+	matches, err := matchKeyRows(file, sheetName, key, columns, elements)
+	if err != nil {
+		return err
+	}
+	if matches.keyIndex < 0 {
+		return &MissingColumnsError{SheetName: sheetName, Columns: []string{key.name}}
+	}
+
+	matched := map[int]bool{}
+	for rowIdx, i := range matches.rows {
+		matched[i] = true
+		if err := updateRow(file, sheetName, slice.Index(i), rowIdx, matches.columns, nil); err != nil {
+			return err
+		}
+	}
+
+	// Appended rows keep the data order, the first element with the key is written
+	key.index = matches.keyIndex
+	appended := append([]column{key}, matches.columns...)
+	styles := map[int]int{}
+	if matches.lastRow > 0 {
+		for _, c := range appended {
+			if styles[c.index], err = file.GetCellStyle(sheetName, GetCellName(c.index, matches.lastRow)); err != nil {
+				return err
+			}
+		}
+	}
+	var appendedRows []int
+	for _, i := range elements {
+		if !matched[i] {
+			appendedRows = append(appendedRows, i)
+		}
+	}
+	sort.Ints(appendedRows)
+
+	// The footer moves down below appended rows
+	rowIdx := matches.endRow
+	if matches.footerRow > 0 && len(appendedRows) > 0 {
+		if err := file.InsertRows(sheetName, rowIdx+1, len(appendedRows)); err != nil {
+			return err
+		}
+	}
+	for _, i := range appendedRows {
+		rowIdx++
+		if err := updateRow(file, sheetName, slice.Index(i), rowIdx, appended, styles); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// keyElements maps keys of elements to indexes of the first elements with them
+func keyElements(slice reflect.Value, key column) (map[string]int, error) {
+	elements := map[string]int{}
+	for i := 0; i < slice.Len(); i++ {
+		value, err := key.cellValue(slice.Index(i))
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", key.field.Name, err)
+		}
+		k := fmt.Sprint(value)
+		if _, ok := elements[k]; !ok {
+			elements[k] = i
+		}
+	}
+	return elements, nil
+}
+
+// updateRow writes cells of the columns of the element to the row, styles are set by column index if given
+func updateRow(file *excelize.File, sheetName string, element reflect.Value, rowIdx int, columns []column, styles map[int]int) error {
+	for _, c := range columns {
+		value, err := c.cellValue(element)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", c.field.Name, err)
+		}
+		cell := GetCellName(c.index, rowIdx)
+		if err := setCellValue(file, sheetName, cell, value); err != nil {
+			return err
+		}
+		if style, ok := styles[c.index]; ok {
+			if err := file.SetCellStyle(sheetName, cell, cell, style); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fieldColumn returns the column of the field with the name, nested fields are named like "Audit.CreatedAt"
+func fieldColumn(t reflect.Type, name string) (column, bool) {
+	for _, c := range getColumns(t) {
+		if c.field.Name == name {
+			return c, true
+		}
+	}
+	return column{}, false
+}
+
+// headerSearchRows is how many top rows are searched for the header of sheets changed in place,
+// rows above it like the banner of WithBanner are left as they are
+const headerSearchRows = 5
+
+// keyRows are sheet rows matched with elements by keys
+type keyRows struct {
+	// columns are updated columns with indexes of their sheet columns
+	columns []column
+	// rows maps row indexes to element indexes
+	rows map[int]int
+	// missing are headers of columns and the key not found in the header
+	missing []string
+	// keyIndex is the index of the key column, -1 if it isn't found
+	keyIndex int
+	// headerRow is the row with the key header, 0 if it isn't found
+	headerRow int
+	// lastRow is the index of the last row with the key, 0 if there are none
+	lastRow int
+	// endRow is the last row of the header, the type row, totals, summaries or data, rows are appended after it
+	endRow int
+	// footerRow is the first footer row written by Write, 0 if there is none
+	footerRow int
+}
+
+// matchKeyRows finds columns in the header and rows with keys of elements, headers not found are missing
+// The header is the first of top rows with the key, missing headers are reported by the first row without it
+// Cells are compared as they are stored and as they are shown, so numbers and dates match either way
+func matchKeyRows(file *excelize.File, sheetName string, key column, columns []column, elements map[string]int) (*keyRows, error) {
+	o := UnmarshalOptions{}.withDefaults()
+	rows, err := newRowReader(file, sheetName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	m := &keyRows{rows: map[int]int{}, keyIndex: -1}
+	var first, keyHeader *header
+	emptyRows := 0
+	for rows.Next() && emptyRows < o.EmptyRowGap {
+		raw, formatted, err := rows.Row()
+		if err != nil {
+			return nil, err
+		}
+
+		if m.headerRow == 0 {
+			h := parseHeader(formatted, o)
+			if first == nil {
+				first = h
+			}
+			if _, _, ok := h.findColumn(key); ok {
+				m.headerRow, m.endRow, keyHeader = rows.rowIdx, rows.rowIdx, h
+				m.matchHeader(h, key, columns)
+				continue
+			}
+			if rows.rowIdx < headerSearchRows {
+				continue
+			}
+			break
+		}
+
+		// Rows written by Write which aren't data are skipped, the data ends at the footer
+		if kind := keyHeader.rowKind(raw); kind == footerRowKind {
+			m.footerRow = rows.rowIdx
+			break
+		} else if kind != "" {
+			m.endRow = rows.rowIdx
+			continue
+		}
+		if rows.rowIdx == m.headerRow+1 {
+			if _, ok := parseTypeRow(file, sheetName, rows.rowIdx, raw); ok {
+				m.endRow = rows.rowIdx
+				continue
+			}
+		}
+
+		rawKey, formattedKey := strings.TrimSpace(cellAt(raw, m.keyIndex)), strings.TrimSpace(cellAt(formatted, m.keyIndex))
+		if rawKey == "" {
+			emptyRows++
+			continue
+		}
+		emptyRows, m.lastRow, m.endRow = 0, rows.rowIdx, rows.rowIdx
+		if i, ok := elements[rawKey]; ok {
+			m.rows[rows.rowIdx] = i
+		} else if i, ok := elements[formattedKey]; ok {
+			m.rows[rows.rowIdx] = i
+		}
+	}
+
+	// Rows can't be matched without the key
+	if m.headerRow == 0 && first != nil {
+		m.matchHeader(first, key, columns)
+	}
+	return m, nil
+}
+
+// matchHeader finds the key and columns in the header, headers not found are missing
+func (m *keyRows) matchHeader(h *header, key column, columns []column) {
+	for _, c := range append([]column{key}, columns...) {
+		columnIdx, _, ok := h.findColumn(c)
+		if !ok {
+			m.missing = append(m.missing, c.name)
+			continue
+		}
+		c.index = columnIdx
+		if c.field.Name == key.field.Name && m.keyIndex < 0 {
+			m.keyIndex = columnIdx
+		} else {
+			m.columns = append(m.columns, c)
+		}
+	}
+}
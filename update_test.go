@@ -0,0 +1,118 @@
+package xlsx
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type updateProduct struct {
+	SKU   string
+	Price float64
+}
+
+// writeWithoutKey writes a sheet with data rows and no SKU column
+func writeWithoutKey(t *testing.T) *excelize.File {
+	type product struct {
+		Name  string
+		Price float64
+	}
+	file := excelize.NewFile()
+	if err := Write(file, "Products", []product{{"a", 1}, {"b", 2}}); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestUpdateColumnsMissingKey(t *testing.T) {
+	file := writeWithoutKey(t)
+	err := UpdateColumns(file, "Products", []updateProduct{{"a", 10}}, "SKU", "Price")
+	var missing *MissingColumnsError
+	if !errors.As(err, &missing) || len(missing.Columns) != 1 || missing.Columns[0] != "SKU" {
+		t.Fatalf("got %v, want missing SKU column", err)
+	}
+}
+
+func TestUpdateByKeyMissingKey(t *testing.T) {
+	file := writeWithoutKey(t)
+	err := UpdateByKey(file, "Products", "SKU", []updateProduct{{"a", 10}})
+	var missing *MissingColumnsError
+	if !errors.As(err, &missing) || len(missing.Columns) != 1 || missing.Columns[0] != "SKU" {
+		t.Fatalf("got %v, want missing SKU column", err)
+	}
+}
+
+func TestUpdateBannerSheet(t *testing.T) {
+	file := excelize.NewFile()
+	data := []updateProduct{{"a", 1}, {"b", 2}}
+	if err := Write(file, "Products", data, WithBanner("Prices")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := UpdateColumns(file, "Products", []updateProduct{{"b", 20}}, "SKU", "Price"); err != nil {
+		t.Fatal(err)
+	}
+	if err := UpdateByKey(file, "Products", "SKU", []updateProduct{{"a", 10}, {"c", 30}}); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := file.GetRows("Products")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{{"Prices"}, {"SKU", "Price"}, {"a", "10"}, {"b", "20"}, {"c", "30"}}
+	if len(rows) != len(want) {
+		t.Fatalf("got %v, want %v", rows, want)
+	}
+	for i := range want {
+		for j := range want[i] {
+			if rows[i][j] != want[i][j] {
+				t.Fatalf("got %v, want %v", rows, want)
+			}
+		}
+	}
+}
+
+func TestUpdateByKeyBeforeFooter(t *testing.T) {
+	file := excelize.NewFile()
+	data := []updateProduct{{"a", 1}, {"b", 2}}
+	if err := Write(file, "Products", data, WithFooterRows([]string{"foot"})); err != nil {
+		t.Fatal(err)
+	}
+	dataStyle, err := file.GetCellStyle("Products", "A3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := UpdateByKey(file, "Products", "SKU", []updateProduct{{"c", 3}}); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := file.GetRows("Products")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{{"SKU", "Price"}, {"a", "1"}, {"b", "2"}, {"c", "3"}, nil, {"foot"}}
+	if len(rows) != len(want) {
+		t.Fatalf("got %v, want %v", rows, want)
+	}
+	for i := range want {
+		for j := range want[i] {
+			if rows[i][j] != want[i][j] {
+				t.Fatalf("got %v, want %v", rows, want)
+			}
+		}
+	}
+	if style, err := file.GetCellStyle("Products", "A4"); err != nil || style != dataStyle {
+		t.Fatalf("appended cell style %d, want %d of data cells", style, dataStyle)
+	}
+
+	var got []updateProduct
+	if err := UnmarshalSheet(file, "Products", &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("read %v, want 3 products", got)
+	}
+}
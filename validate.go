@@ -0,0 +1,92 @@
+package xlsx
+
+import (
+	"errors"
+	"reflect"
+)
+
+// RowContext is the read row passed to the row validator
+type RowContext struct {
+	SheetName string
+	// RowIdx is the sheet row index
+	RowIdx int
+	// Element is the pointer to the decoded struct
+	Element interface{}
+	// Cells are mapped cells of the row by field name, nested fields are named like "Audit.CreatedAt"
+	Cells map[string]RowCell
+}
+
+// RowCell is the cell of the field read by Unmarshal
+type RowCell struct {
+	// Cell is the cell name like "C5"
+	Cell   string
+	Header string
+	// Raw is the stored value, Formatted is the value as it is shown
+	Raw       string
+	Formatted string
+}
+
+// CellError returns the error citing the cell of the field, e.g. ctx.CellError("EndDate", errors.New("before StartDate"))
+// The error has no cell if the field isn't mapped
+func (ctx *RowContext) CellError(field string, err error) *CellError {
+	cell := ctx.Cells[field]
+	return &CellError{SheetName: ctx.SheetName, Cell: cell.Cell, Header: cell.Header, Field: field, Raw: cell.Raw, Err: err,
+		invalid: true}
+}
+
+// WithRowValidator sets the validator of decoded rows of structs with rules across fields like "EndDate after StartDate"
+// CellError and CellErrors of the validator are collected with CollectErrors like cells which can't be converted,
+// other errors and all errors without CollectErrors stop Unmarshal
+func WithRowValidator(fn func(ctx *RowContext) error) Option {
+	return func(o *options) {
+		o.read.RowValidator = fn
+	}
+}
+
+// validate checks validation tags of the decoded element of the row and calls the row validator, see checkRules
+// Violations of tags are returned at once or collected with CollectErrors
+func (d *cellDecoder) validate(element reflect.Value, columns []column, raw []string, formatted []string) error {
+	ctx := &RowContext{SheetName: d.sheetName, RowIdx: d.rows.rowIdx, Element: element.Addr().Interface(), Cells: map[string]RowCell{}}
+	for _, c := range columns {
+		if c.restKey != "" {
+			continue
+		}
+		ctx.Cells[c.field.Name] = RowCell{
+			Cell:      GetCellName(c.index, d.rows.rowIdx),
+			Header:    c.name,
+			Raw:       cellAt(raw, c.index),
+			Formatted: cellAt(formatted, c.index),
+		}
+
+		problems, err := checkRules(element, c)
+		if err != nil {
+			return err
+		}
+		for _, problem := range problems {
+			cellErr := ctx.CellError(c.field.Name, problem)
+			if !d.o.CollectErrors {
+				return cellErr
+			}
+			d.errors = append(d.errors, cellErr)
+		}
+	}
+	if d.o.RowValidator == nil {
+		return nil
+	}
+
+	err := d.o.RowValidator(ctx)
+	if err == nil || !d.o.CollectErrors {
+		return err
+	}
+	var cellErrors CellErrors
+	var cellErr *CellError
+	switch {
+	case errors.As(err, &cellErrors):
+		d.errors = append(d.errors, cellErrors...)
+	case errors.As(err, &cellErr):
+		d.errors = append(d.errors, cellErr)
+	default:
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package xlsx
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Values returns the header and cell values of data as Write would write them, rows[0] is the header
+// Rows are laid out by column indexes, skipped columns are empty strings
+// It's the bridge to other spreadsheet backends, only field hooks options are supported
+func Values(data interface{}, opts ...Option) ([][]interface{}, error) {
+	o := newOptions(opts)
+
+	if reflect.TypeOf(data).Kind() != reflect.Slice {
+		return nil, fmt.Errorf("slice only is allowed")
+	}
+
+	slice := reflect.ValueOf(data)
+	var columns []column
+	switch {
+	case slice.Type().Elem().Kind() == reflect.Struct:
+		columns = appendRestColumns(getColumns(slice.Type().Elem()), slice)
+	case slice.Type().Elem() == reflect.TypeOf(map[string]interface{}{}):
+		columns = getMapColumns(slice, o.write.ColumnOrder)
+	default:
+		return nil, fmt.Errorf("slice of structs only is allowed")
+	}
+	if err := checkColumns(columns); err != nil {
+		return nil, err
+	}
+	if len(columns) == 0 {
+		return nil, nil
+	}
+
+	width := lastColumnIndex(columns) + 1
+	newRow := func() []interface{} {
+		row := make([]interface{}, width)
+		for i := range row {
+			row[i] = ""
+		}
+		return row
+	}
+
+	header := newRow()
+	for _, c := range columns {
+		header[c.index] = c.name
+	}
+	rows := [][]interface{}{header}
+
+	for rowi := 0; rowi < slice.Len(); rowi++ {
+		element := slice.Index(rowi)
+		row := newRow()
+		for _, c := range columns {
+			cellValue, err := c.cellValue(element)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", c.field.Name, err)
+			}
+			cellValue, link, err := c.hyperlink(element, cellValue)
+			if err != nil {
+				return nil, err
+			}
+
+			if o.write.OnField != nil {
+				ctx := WriteContext{
+					Index:     rowi,
+					Element:   element.Interface(),
+					RowIdx:    rowi + 2,
+					ColumnIdx: c.index,
+					Cell:      GetCellName(c.index, rowi+2),
+					Field:     c.field,
+					Value:     cellValue,
+				}
+				if err := o.write.OnField(&ctx); err != nil {
+					return nil, err
+				}
+				cellValue = ctx.Value
+			}
+			formula, err := c.formula(columns, rowi+2)
+			if err != nil {
+				return nil, err
+			}
+			if formula != "" {
+				// Spreadsheets parse values starting with "=" as formulas
+				cellValue = "=" + formula
+			} else if target, linkType := hyperlinkTarget(link); target != "" && linkType == "External" {
+				cellValue = hyperlinkFormula(cellValue, target)
+			}
+			row[c.index] = cellValue
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
@@ -0,0 +1,55 @@
+package xlsx
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// WithTemplateVersion reads sheets of the template version, tags scoped to it like `xlsx:"name[v2]:New Header"`
+// replace tags with the same name, tags scoped to other versions are ignored
+// One struct can read all template versions in circulation this way, Write uses tags without a version
+func WithTemplateVersion(version string) Option {
+	return func(o *options) {
+		o.read.TemplateVersion = version
+	}
+}
+
+// versionTag returns the struct tag with xlsx tags of the version, e.g. name[v2]:B becomes name:B,
+// and without tags scoped to other versions
+func versionTag(tag reflect.StructTag, version string) reflect.StructTag {
+	value, ok := tag.Lookup("xlsx")
+	if !ok || !strings.Contains(value, "[") {
+		return tag
+	}
+
+	entries := strings.Split(value, ";")
+	versioned := map[string]bool{}
+	for _, entry := range entries {
+		if name, v, ok := tagVersion(entry); ok && v == version {
+			versioned[name] = true
+		}
+	}
+	kept := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		name, v, ok := tagVersion(entry)
+		switch {
+		case ok && v == version:
+			kept = append(kept, name+strings.TrimPrefix(entry, name+"["+v+"]"))
+		case !ok && !versioned[name]:
+			kept = append(kept, entry)
+		}
+	}
+	// Columns read xlsx tags only, so other keys aren't kept
+	return reflect.StructTag("xlsx:" + strconv.Quote(strings.Join(kept, ";")))
+}
+
+// tagVersion splits the tag entry like name[v2]:B into the tag name and the version, false without the version
+func tagVersion(entry string) (string, string, bool) {
+	name := strings.SplitN(entry, ":", 2)[0]
+	open := strings.Index(name, "[")
+	if open < 0 || !strings.HasSuffix(name, "]") {
+		return name, "", false
+	}
+	return name[:open], name[open+1 : len(name)-1], true
+}
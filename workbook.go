@@ -0,0 +1,94 @@
+package xlsx
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// WriteWorkbook writes every slice of data to its own sheet with Write
+// data is a struct with slice fields named by the sheet tag like `xlsx:"sheet:Customers"` or by the field name,
+// sheets follow the field order, or map[string]interface{} of sheet names and slices, sheets follow in name order
+// Options apply to all sheets
+func WriteWorkbook(file *excelize.File, data interface{}, opts ...Option) error {
+	sheets, err := workbookSheets(data)
+	if err != nil {
+		return err
+	}
+	for _, s := range sheets {
+		if err := Write(file, s.name, s.value.Interface(), opts...); err != nil {
+			return fmt.Errorf("sheet %s: %w", s.name, err)
+		}
+	}
+	return nil
+}
+
+// UnmarshalWorkbook reads sheets into slice fields of the struct v points to like UnmarshalSheet,
+// sheets are named by the sheet tag like `xlsx:"sheet:Customers"` or by field names, see WriteWorkbook
+// Options apply to all sheets
+func UnmarshalWorkbook(file *excelize.File, v interface{}, opts ...Option) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("pointer to struct is required")
+	}
+	sheets, err := workbookSheets(v)
+	if err != nil {
+		return err
+	}
+	for _, s := range sheets {
+		if err := UnmarshalSheet(file, s.name, s.value.Addr().Interface(), opts...); err != nil {
+			return fmt.Errorf("sheet %s: %w", s.name, err)
+		}
+	}
+	return nil
+}
+
+// workbookSheet is the slice of the sheet, fields of the struct v points to are addressable
+type workbookSheet struct {
+	name  string
+	value reflect.Value
+}
+
+// workbookSheets returns slices of the workbook struct or map in the sheet order
+func workbookSheets(data interface{}) ([]workbookSheet, error) {
+	v := reflect.Indirect(reflect.ValueOf(data))
+	var sheets []workbookSheet
+	switch {
+	case v.Kind() == reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() || field.Tag.Get("xlsx") == "-" {
+				continue
+			}
+			if field.Type.Kind() != reflect.Slice {
+				return nil, fmt.Errorf("field %s: slice only is allowed", field.Name)
+			}
+			name := getTag(field, "sheet")
+			if name == "" {
+				name = field.Name
+			}
+			sheets = append(sheets, workbookSheet{name: name, value: v.Field(i)})
+		}
+	case v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String:
+		keys := make([]string, 0, v.Len())
+		for _, key := range v.MapKeys() {
+			keys = append(keys, key.String())
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			value := v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key()))
+			if value.Kind() == reflect.Interface {
+				value = value.Elem()
+			}
+			if value.Kind() != reflect.Slice {
+				return nil, fmt.Errorf("sheet %s: slice only is allowed", key)
+			}
+			sheets = append(sheets, workbookSheet{name: key, value: value})
+		}
+	default:
+		return nil, fmt.Errorf("struct of slices or map of sheet names and slices only is allowed")
+	}
+	return sheets, nil
+}
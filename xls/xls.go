@@ -0,0 +1,587 @@
+// Package xls reads legacy BIFF8 .xls files (Excel 97-2003) as xlsx.CellSource
+//
+//	src, err := xls.Open("old.xls")
+//	...
+//	err = xlsx.UnmarshalSource(src, "Sheet1", &rows)
+//
+// Only cell values are read, formulas are read as their cached results
+package xls
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	"github.com/boltegg/xlsx"
+	"github.com/richardlehane/mscfb"
+	"github.com/xuri/excelize/v2"
+)
+
+// BIFF8 record types
+const (
+	recordFormula    = 0x0006
+	recordEOF        = 0x000A
+	recordDateMode   = 0x0022
+	recordContinue   = 0x003C
+	recordBoundSheet = 0x0085
+	recordMulRK      = 0x00BD
+	recordXF         = 0x00E0
+	recordSST        = 0x00FC
+	recordLabelSST   = 0x00FD
+	recordNumber     = 0x0203
+	recordLabel      = 0x0204
+	recordBoolErr    = 0x0205
+	recordString     = 0x0207
+	recordRK         = 0x027E
+	recordFormat     = 0x041E
+	recordBOF        = 0x0809
+)
+
+// biff8 is the BOF version of Excel 97-2003 files
+const biff8 = 0x0600
+
+// ErrNotBIFF8 is returned for files older than Excel 97
+var ErrNotBIFF8 = errors.New("xls: only BIFF8 (Excel 97-2003) files are supported")
+
+// errMalformedSST is returned for shared string tables with characters split between records
+var errMalformedSST = errors.New("xls: malformed SST")
+
+type cell struct {
+	raw       string
+	formatted string
+	ctype     excelize.CellType
+}
+
+type sheet struct {
+	cells   map[string]cell
+	rows    int
+	columns int
+}
+
+// Source is the CellSource of a legacy .xls file
+type Source struct {
+	names  []string
+	sheets map[string]*sheet
+}
+
+// Open reads the .xls file
+func Open(name string) (*Source, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return OpenReader(f)
+}
+
+// OpenReader reads the .xls file from r
+func OpenReader(r io.ReaderAt) (*Source, error) {
+	doc, err := mscfb.New(r)
+	if err != nil {
+		return nil, err
+	}
+	for entry, err := doc.Next(); err == nil; entry, err = doc.Next() {
+		switch entry.Name {
+		case "Workbook":
+			stream, err := io.ReadAll(entry)
+			if err != nil {
+				return nil, err
+			}
+			return parseWorkbook(stream)
+		case "Book":
+			return nil, ErrNotBIFF8
+		}
+	}
+	return nil, errors.New("xls: workbook stream not found")
+}
+
+// GetSheetList returns names of worksheets in the workbook order
+func (s *Source) GetSheetList() []string {
+	return append([]string(nil), s.names...)
+}
+
+// GetCellValue returns the formatted cell value, the raw one with excelize.Options{RawCellValue: true}
+// Raw values of dates are serial numbers of the 1900 date system
+func (s *Source) GetCellValue(sheetName string, cellName string, opts ...excelize.Options) (string, error) {
+	sh, ok := s.sheets[sheetName]
+	if !ok {
+		return "", fmt.Errorf("sheet %s does not exist", sheetName)
+	}
+	c := sh.cells[cellName]
+	if len(opts) > 0 && opts[0].RawCellValue {
+		return c.raw, nil
+	}
+	return c.formatted, nil
+}
+
+// GetCellType returns the type of the cell value, the result type for formulas
+func (s *Source) GetCellType(sheetName string, cellName string) (excelize.CellType, error) {
+	sh, ok := s.sheets[sheetName]
+	if !ok {
+		return excelize.CellTypeUnset, fmt.Errorf("sheet %s does not exist", sheetName)
+	}
+	return sh.cells[cellName].ctype, nil
+}
+
+// Dimensions returns the number of used rows and columns of the sheet
+func (s *Source) Dimensions(sheetName string) (int, int, error) {
+	sh, ok := s.sheets[sheetName]
+	if !ok {
+		return 0, 0, fmt.Errorf("sheet %s does not exist", sheetName)
+	}
+	return sh.rows, sh.columns, nil
+}
+
+type record struct {
+	id   uint16
+	data []byte
+}
+
+// readRecord returns the record at the offset and the offset of the next one
+func readRecord(stream []byte, offset int) (record, int, error) {
+	if offset+4 > len(stream) {
+		return record{}, 0, io.ErrUnexpectedEOF
+	}
+	id := binary.LittleEndian.Uint16(stream[offset:])
+	size := int(binary.LittleEndian.Uint16(stream[offset+2:]))
+	end := offset + 4 + size
+	if end > len(stream) {
+		return record{}, 0, io.ErrUnexpectedEOF
+	}
+	return record{id: id, data: stream[offset+4 : end]}, end, nil
+}
+
+type boundSheet struct {
+	name   string
+	offset int
+}
+
+// workbook is the state of the globals substream needed to read sheets
+type workbook struct {
+	date1904 bool
+	formats  map[uint16]string
+	xfs      []uint16
+	strings  []string
+}
+
+func parseWorkbook(stream []byte) (*Source, error) {
+	wb := &workbook{formats: map[uint16]string{}}
+	var sheets []boundSheet
+
+	rec, offset, err := readRecord(stream, 0)
+	if err != nil {
+		return nil, err
+	}
+	if rec.id != recordBOF || len(rec.data) < 2 || binary.LittleEndian.Uint16(rec.data) != biff8 {
+		return nil, ErrNotBIFF8
+	}
+
+globals:
+	for {
+		rec, offset, err = readRecord(stream, offset)
+		if err != nil {
+			return nil, err
+		}
+		switch rec.id {
+		case recordEOF:
+			break globals
+		case recordDateMode:
+			wb.date1904 = len(rec.data) >= 2 && binary.LittleEndian.Uint16(rec.data) == 1
+		case recordFormat:
+			if len(rec.data) >= 2 {
+				wb.formats[binary.LittleEndian.Uint16(rec.data)] = readString(rec.data[2:], 2)
+			}
+		case recordXF:
+			if len(rec.data) >= 4 {
+				wb.xfs = append(wb.xfs, binary.LittleEndian.Uint16(rec.data[2:]))
+			}
+		case recordBoundSheet:
+			// Only worksheets, not charts or macro sheets
+			if len(rec.data) >= 8 && rec.data[5] == 0 {
+				name := readString(rec.data[6:], 1)
+				sheets = append(sheets, boundSheet{name: name, offset: int(binary.LittleEndian.Uint32(rec.data))})
+			}
+		case recordSST:
+			segments := [][]byte{rec.data}
+			for {
+				next, nextOffset, err := readRecord(stream, offset)
+				if err != nil || next.id != recordContinue {
+					break
+				}
+				segments = append(segments, next.data)
+				offset = nextOffset
+			}
+			if wb.strings, err = readSST(segments); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	src := &Source{sheets: map[string]*sheet{}}
+	for _, bs := range sheets {
+		sh, err := wb.parseSheet(stream, bs.offset)
+		if err != nil {
+			return nil, fmt.Errorf("xls: sheet %s: %w", bs.name, err)
+		}
+		src.names = append(src.names, bs.name)
+		src.sheets[bs.name] = sh
+	}
+	return src, nil
+}
+
+func (wb *workbook) parseSheet(stream []byte, offset int) (*sheet, error) {
+	sh := &sheet{cells: map[string]cell{}}
+
+	rec, offset, err := readRecord(stream, offset)
+	if err != nil {
+		return nil, err
+	}
+	if rec.id != recordBOF {
+		return nil, errors.New("substream doesn't start with BOF")
+	}
+
+	// String result of the previous formula comes in the following STRING record
+	formulaCell := ""
+	// Embedded charts are substreams inside the sheet substream
+	depth := 0
+	for {
+		rec, offset, err = readRecord(stream, offset)
+		if err != nil {
+			return nil, err
+		}
+		data := rec.data
+		switch rec.id {
+		case recordBOF:
+			depth++
+		case recordEOF:
+			if depth == 0 {
+				return sh, nil
+			}
+			depth--
+		case recordLabelSST:
+			if len(data) >= 10 {
+				isst := int(binary.LittleEndian.Uint32(data[6:]))
+				if isst < len(wb.strings) {
+					sh.setString(cellName(data), wb.strings[isst])
+				}
+			}
+		case recordLabel:
+			if len(data) >= 8 {
+				s := readString(data[6:], 2)
+				sh.setString(cellName(data), s)
+			}
+		case recordNumber:
+			if len(data) >= 14 {
+				wb.setNumber(sh, cellName(data), cellXF(data), math.Float64frombits(binary.LittleEndian.Uint64(data[6:])))
+			}
+		case recordRK:
+			if len(data) >= 10 {
+				wb.setNumber(sh, cellName(data), cellXF(data), decodeRK(binary.LittleEndian.Uint32(data[6:])))
+			}
+		case recordMulRK:
+			if len(data) >= 6 {
+				rowIdx := int(binary.LittleEndian.Uint16(data)) + 1
+				columnIdx := int(binary.LittleEndian.Uint16(data[2:]))
+				// xf and rk pairs followed by the last column
+				for i := 4; i+6 <= len(data)-2; i += 6 {
+					xf := binary.LittleEndian.Uint16(data[i:])
+					wb.setNumber(sh, xlsx.GetCellName(columnIdx, rowIdx), xf, decodeRK(binary.LittleEndian.Uint32(data[i+2:])))
+					columnIdx++
+				}
+			}
+		case recordBoolErr:
+			if len(data) >= 8 && data[7] == 0 {
+				sh.setBool(cellName(data), data[6] != 0)
+			}
+		case recordFormula:
+			formulaCell = ""
+			if len(data) < 14 {
+				continue
+			}
+			result := data[6:14]
+			if result[6] != 0xFF || result[7] != 0xFF {
+				wb.setNumber(sh, cellName(data), cellXF(data), math.Float64frombits(binary.LittleEndian.Uint64(result)))
+				continue
+			}
+			switch result[0] {
+			case 0:
+				formulaCell = cellName(data)
+			case 1:
+				sh.setBool(cellName(data), result[2] != 0)
+			case 3:
+				sh.setString(cellName(data), "")
+			}
+		case recordString:
+			if formulaCell != "" {
+				s := readString(data, 2)
+				sh.set(formulaCell, cell{raw: s, formatted: s, ctype: excelize.CellTypeSharedString})
+				formulaCell = ""
+			}
+		}
+	}
+}
+
+// cellName returns the name of the cell record's cell
+func cellName(data []byte) string {
+	return xlsx.GetCellName(int(binary.LittleEndian.Uint16(data[2:])), int(binary.LittleEndian.Uint16(data))+1)
+}
+
+// cellXF returns the index of the cell record's XF record
+func cellXF(data []byte) uint16 {
+	return binary.LittleEndian.Uint16(data[4:])
+}
+
+func (sh *sheet) set(name string, c cell) {
+	columnIdx, rowIdx, err := xlsx.ParseCellName(name)
+	if err != nil {
+		return
+	}
+	if rowIdx > sh.rows {
+		sh.rows = rowIdx
+	}
+	if columnIdx+1 > sh.columns {
+		sh.columns = columnIdx + 1
+	}
+	sh.cells[name] = c
+}
+
+func (sh *sheet) setString(name string, s string) {
+	if s == "" {
+		return
+	}
+	sh.set(name, cell{raw: s, formatted: s, ctype: excelize.CellTypeSharedString})
+}
+
+func (sh *sheet) setBool(name string, b bool) {
+	c := cell{raw: "0", formatted: "FALSE", ctype: excelize.CellTypeBool}
+	if b {
+		c.raw, c.formatted = "1", "TRUE"
+	}
+	sh.set(name, c)
+}
+
+// setNumber formats the number by the cell's XF record, dates are shifted to the 1900 date system
+func (wb *workbook) setNumber(sh *sheet, name string, xf uint16, f float64) {
+	raw := strconv.FormatFloat(f, 'f', -1, 64)
+	c := cell{raw: raw, formatted: raw, ctype: excelize.CellTypeNumber}
+
+	if int(xf) < len(wb.xfs) && wb.isDateFormat(wb.xfs[xf]) {
+		t, err := excelize.ExcelDateToTime(f, wb.date1904)
+		if err == nil {
+			if wb.date1904 {
+				f += 1462
+				c.raw = strconv.FormatFloat(f, 'f', -1, 64)
+			}
+			c.formatted = formatTime(t, f)
+		}
+	}
+	sh.set(name, c)
+}
+
+func formatTime(t time.Time, serial float64) string {
+	switch {
+	case serial < 1:
+		return t.Format("15:04:05")
+	case serial == math.Trunc(serial):
+		return t.Format("2006-01-02")
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
+
+// isDateFormat reports whether the number format shows dates or times
+func (wb *workbook) isDateFormat(numFmt uint16) bool {
+	return xlsx.IsDateFormat(int(numFmt), wb.formats[numFmt])
+}
+
+// decodeRK converts the RK number, the compressed representation of integers and floats
+func decodeRK(rk uint32) float64 {
+	var f float64
+	if rk&0x02 != 0 {
+		f = float64(int32(rk) >> 2)
+	} else {
+		f = math.Float64frombits(uint64(rk&0xFFFFFFFC) << 32)
+	}
+	if rk&0x01 != 0 {
+		f /= 100
+	}
+	return f
+}
+
+// readString reads the unicode string with the length field of lenSize bytes
+func readString(data []byte, lenSize int) string {
+	if len(data) < lenSize+1 {
+		return ""
+	}
+	n := int(data[0])
+	if lenSize == 2 {
+		n = int(binary.LittleEndian.Uint16(data))
+	}
+	flags := data[lenSize]
+	data = data[lenSize+1:]
+	if flags&0x01 == 0 {
+		if n > len(data) {
+			n = len(data)
+		}
+		return decodeCompressed(data[:n])
+	}
+	if 2*n > len(data) {
+		n = len(data) / 2
+	}
+	return decodeUTF16(data[:2*n])
+}
+
+// decodeCompressed decodes the string of UTF-16 characters without high bytes
+func decodeCompressed(b []byte) string {
+	runes := make([]rune, len(b))
+	for i, ch := range b {
+		runes[i] = rune(ch)
+	}
+	return string(runes)
+}
+
+func decodeUTF16(b []byte) string {
+	units := make([]uint16, len(b)/2)
+	for i := range units {
+		units[i] = binary.LittleEndian.Uint16(b[2*i:])
+	}
+	return string(utf16.Decode(units))
+}
+
+// sstReader reads the shared string table split into SST and CONTINUE records
+type sstReader struct {
+	segments [][]byte
+	segment  int
+	pos      int
+}
+
+// next returns the rest of the current segment moving to the next one if it's read
+func (r *sstReader) next() ([]byte, bool) {
+	for r.pos >= len(r.segments[r.segment]) {
+		if r.segment+1 >= len(r.segments) {
+			return nil, false
+		}
+		r.segment++
+		r.pos = 0
+	}
+	return r.segments[r.segment][r.pos:], true
+}
+
+// read returns n bytes which may span CONTINUE records
+func (r *sstReader) read(n int) ([]byte, error) {
+	var buf []byte
+	for n > 0 {
+		data, ok := r.next()
+		if !ok {
+			return nil, io.ErrUnexpectedEOF
+		}
+		if len(data) > n {
+			data = data[:n]
+		}
+		buf = append(buf, data...)
+		r.pos += len(data)
+		n -= len(data)
+	}
+	return buf, nil
+}
+
+// readChars reads n characters, a CONTINUE record in the middle starts with the new flags byte
+func (r *sstReader) readChars(n int, highByte bool) (string, error) {
+	var sb strings.Builder
+	started := false
+	for n > 0 {
+		data, ok := r.next()
+		if !ok {
+			return "", io.ErrUnexpectedEOF
+		}
+		if r.pos == 0 && started {
+			highByte = data[0]&0x01 != 0
+			r.pos++
+			continue
+		}
+		started = true
+		if highByte {
+			// Characters are never split between records
+			if len(data) < 2 {
+				return "", errMalformedSST
+			}
+			count := len(data) / 2
+			if count > n {
+				count = n
+			}
+			sb.WriteString(decodeUTF16(data[:2*count]))
+			r.pos += 2 * count
+			n -= count
+		} else {
+			count := len(data)
+			if count > n {
+				count = n
+			}
+			sb.WriteString(decodeCompressed(data[:count]))
+			r.pos += count
+			n -= count
+		}
+	}
+	return sb.String(), nil
+}
+
+func readSST(segments [][]byte) ([]string, error) {
+	r := &sstReader{segments: segments}
+	header, err := r.read(8)
+	if err != nil {
+		return nil, err
+	}
+	count := int(binary.LittleEndian.Uint32(header[4:]))
+
+	// The count comes from the file, every string takes at least 3 bytes of its header
+	size := 0
+	for _, segment := range segments {
+		size += len(segment)
+	}
+	capacity := count
+	if capacity > size/3 {
+		capacity = size / 3
+	}
+	strs := make([]string, 0, capacity)
+	for i := 0; i < count; i++ {
+		head, err := r.read(3)
+		if err != nil {
+			return nil, err
+		}
+		n := int(binary.LittleEndian.Uint16(head))
+		flags := head[2]
+
+		runs, extSize := 0, 0
+		if flags&0x08 != 0 {
+			b, err := r.read(2)
+			if err != nil {
+				return nil, err
+			}
+			runs = int(binary.LittleEndian.Uint16(b))
+		}
+		if flags&0x04 != 0 {
+			b, err := r.read(4)
+			if err != nil {
+				return nil, err
+			}
+			extSize = int(binary.LittleEndian.Uint32(b))
+		}
+
+		s, err := r.readChars(n, flags&0x01 != 0)
+		if err != nil {
+			return nil, err
+		}
+		// Formatting runs and phonetic data aren't needed
+		if _, err := r.read(4*runs + extSize); err != nil {
+			return nil, err
+		}
+		strs = append(strs, s)
+	}
+	return strs, nil
+}
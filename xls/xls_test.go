@@ -0,0 +1,29 @@
+package xls
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestReadSSTOddByte(t *testing.T) {
+	// One UTF-16 string of two characters with a byte of the second one left before CONTINUE
+	segments := [][]byte{{1, 0, 0, 0, 1, 0, 0, 0, 2, 0, 1, 0x41}, {0x42, 0}}
+	if _, err := readSST(segments); !errors.Is(err, errMalformedSST) {
+		t.Fatalf("got %v, want %v", err, errMalformedSST)
+	}
+}
+
+func FuzzReadSST(f *testing.F) {
+	f.Add([]byte{1, 0, 0, 0, 1, 0, 0, 0, 2, 0, 1, 0x41}, []byte{0x42, 0})
+	f.Add([]byte{1, 0, 0, 0, 1, 0, 0, 0, 2, 0, 0, 'a', 'b'}, []byte{})
+	f.Fuzz(func(t *testing.T, sst []byte, continued []byte) {
+		readSST([][]byte{sst, continued})
+	})
+}
+
+func TestReadSSTHugeCount(t *testing.T) {
+	segments := [][]byte{{0, 0, 0, 0, 0xff, 0xff, 0xff, 0x7f}}
+	if _, err := readSST(segments); err == nil {
+		t.Fatal("got no error for the count past the record")
+	}
+}
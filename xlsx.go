@@ -3,19 +3,36 @@ package xlsx
 import (
 	"bufio"
 	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
 	"fmt"
 	"math"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/xuri/excelize/v2"
 )
 
-func EasyConvert(data interface{}) ([]byte, error) {
+// Marshaler is implemented by types which convert themselves to cell values
+type Marshaler interface {
+	MarshalXLSXCell() (interface{}, error)
+}
+
+// defaultFont is the font of written cells
+var defaultFont = excelize.Font{
+	Family: "Helvetica Neue",
+	Size:   10,
+	Color:  "#000000",
+}
+
+func EasyConvert(data interface{}, opts ...Option) ([]byte, error) {
 	file := excelize.NewFile()
-	err := Write(file, "Data", data)
+	err := Write(file, "Data", data, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -28,118 +45,690 @@ func EasyConvert(data interface{}) ([]byte, error) {
 
 // Write adds new sheet with data
 // support tags:
-// name - column name
+// name - column name, aliases for Unmarshal follow it separated by "|"
+// col - column letter like "C", fields without col or index tags follow the previous field
+// index - one based column number, index:3 is the column C
 // width - column width
+// prefix - header prefix of nested struct fields, the parent name and a dot by default
+// locked - the column can't be changed, other columns are editable in the protected sheet, see UnmarshalOptions.VerifyLocked
 // divide - divide the number
 // round - round the number
-func Write(file *excelize.File, sheetName string, data interface{}) error {
+// children - slice of structs written to its own sheet, the sheet tag names it, the field name by default
+// rest - map[string]string field written as columns named by its keys after other columns
+// key - parent column repeated in every child row, required with children
+// summary - aggregation of the group summary row, see Grouping
+// total - aggregation of the totals row, see WithTotals
+// style - name of the style of data cells, see WithNamedStyles
+// omitempty - empty cell for zero values of any type, nil pointers and values with IsZero returning true
+// negative - look of negative numbers: parens for (1.5), red for red -1.5 or redparens for both
+// unit - units like "kg|%" shown after numbers by the number format, the first one is written, all are trimmed on read
+// formula - formula written instead of the field value like "=B{row}*C{row}" or "={Price}*{Qty}",
+// {row} is the row index, {Name} is the cell of the column with the header or the field name in the row
+// hyperlink - the value is a link like "https://..." or an e-mail address opened with mailto:
+// hyperlink_url - name of the field of the same struct with the link of the value
+// hyperlink_text - name of the field of the same struct with the text shown instead of the link value
+// mergeVertical - consecutive rows with equal values are merged into one cell, Unmarshal reads it with WithMergedCells
+// condfmt - conditional formats of data cells like "red_if_negative|databar", see WithConditionalFormats
+// example - sample value of the blank template, see WriteTemplateHeaders
+// desc - description of the column shown in the comment of the header cell
+// datefmt - number format of time.Time fields written as dates like "dd.mm.yyyy", times are text without it,
+// and of time.Duration fields written as elapsed time, "[h]:mm:ss" by default
+// decimal - encoding.TextMarshaler fields like shopspring Decimal are written as numbers with all digits of their text
+// sep - separator of elements of slice fields like []string written to one cell like "sep:,", Unmarshal splits them
+// default - value of fields of empty cells read by Unmarshal like "0", "UA" or "now" of times
+// nonzero, min, max, regexp, enum - validation rules checked by Unmarshal, see checkRules
+// readonly - the field is only read by Unmarshal and takes no column on write
+// writeonly - the field like a computed value is only written, Unmarshal ignores its column
+// Fields with the "-" tag aren't written and take no column, fields with the same header or column are DuplicateColumnError
+// Fields of types implementing Marshaler convert themselves to cell values, encoding.TextMarshaler ones are written as text
+// and driver.Valuer ones like sql.NullInt64 as their values, invalid ones are empty
+// int64 and uint64 values without divide and round tags are written with all digits, times to the second
+// big.Int, big.Float and big.Rat values are written as numbers with all digits, rationals like 1/3 with 30 decimals
+// data can be []map[string]interface{} too, keys are headers, see WithColumnOrder
+// Tabs of written sheets are ordered by WithSheetOrder, the sheet shown on open is set by WithActiveSheet
+func Write(file *excelize.File, sheetName string, data interface{}, opts ...Option) error {
+	o := newOptions(opts)
+
 	if reflect.TypeOf(data).Kind() != reflect.Slice {
 		return fmt.Errorf("slice only is allowed")
 	}
 
-	file.DeleteSheet(sheetName)
+	slice := reflect.ValueOf(data)
+	var columns []column
+	switch {
+	case slice.Type().Elem().Kind() == reflect.Struct:
+		columns = appendRestColumns(getColumns(slice.Type().Elem()), slice)
+	case slice.Type().Elem() == reflect.TypeOf(map[string]interface{}{}):
+		columns = getMapColumns(slice, o.write.ColumnOrder)
+	default:
+		return fmt.Errorf("slice of structs only is allowed")
+	}
+	if err := checkColumns(columns); err != nil {
+		return err
+	}
+
+	o.progress = newProgress(o.write.OnProgress, slice.Len())
+	o.dataRows = map[string]int{}
+
+	if err := setDateSystem(file, o.write); err != nil {
+		return err
+	}
+	if err := setFullCalcOnLoad(file, o.write); err != nil {
+		return err
+	}
+
+	var r *routes
+	if o.write.SheetRouter != nil {
+		r = routeSheets(sheetName, slice, o.write.SheetRouter)
+	}
+	if len(o.write.SheetOrder) > 0 {
+		if err := createSheets(file, sheetName, slice, r, o); err != nil {
+			return err
+		}
+	}
+
+	var err error
+	if r != nil {
+		err = writeRouted(file, sheetName, slice, columns, r, o)
+	} else {
+		err = writeSheet(file, sheetName, slice, columns, o)
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, _, ok := childrenField(slice.Type().Elem()); ok {
+		if err := writeChildren(file, slice, o); err != nil {
+			return err
+		}
+	}
+
+	if o.write.IndexSheet != "" {
+		if err := writeIndexSheet(file, o.write.IndexSheet, o.dataRows); err != nil {
+			return err
+		}
+	}
+	if o.write.ActiveSheet != "" {
+		return activateSheet(file, o.write.ActiveSheet)
+	}
+	return nil
+}
+
+// setDateSystem switches the workbook to the 1904 date system if the options ask for it
+func setDateSystem(file *excelize.File, o WriteOptions) error {
+	if !o.Date1904 || isDate1904(file) {
+		return nil
+	}
+	date1904 := true
+	return file.SetWorkbookProps(&excelize.WorkbookPropsOptions{Date1904: &date1904})
+}
+
+// setFullCalcOnLoad sets the full calculation on load flag if the options ask for it
+// excelize has no setter of calculation properties, so the flag is set on the calcPr of the workbook,
+// workbooks without one like those after UpdateLinkedValue are an error
+func setFullCalcOnLoad(file *excelize.File, o WriteOptions) error {
+	if !o.FullCalcOnLoad {
+		return nil
+	}
+	// The workbook is loaded by GetWorkbookProps
+	if _, err := file.GetWorkbookProps(); err != nil {
+		return err
+	}
+	if file.WorkBook == nil || file.WorkBook.CalcPr == nil {
+		return fmt.Errorf("full calculation on load can't be set, the workbook has no calculation properties")
+	}
+	file.WorkBook.CalcPr.FullCalcOnLoad = true
+	return nil
+}
+
+// writeRouted writes every element to the sheet returned by the router
+// Sheets are created in order of their first element
+func writeRouted(file *excelize.File, sheetName string, slice reflect.Value, columns []column, r *routes, o *options) error {
+	if len(r.sheetNames) == 0 {
+		return writeSheet(file, sheetName, slice, columns, o)
+	}
+	for _, name := range r.sheetNames {
+		// Provenance rows are looked up by indexes of elements in the whole slice
+		sheetOptions := *o
+		sheetOptions.sourceIndexes = r.indexes[name]
+		if err := writeSheet(file, name, r.sheets[name], columns, &sheetOptions); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// routes are elements of the slice spread across sheets by SheetRouter
+type routes struct {
+	// sheetNames are names of sheets in the order of their first elements
+	sheetNames []string
+	// sheets are elements of sheets
+	sheets map[string]reflect.Value
+	// indexes are indexes of elements of sheets in the slice
+	indexes map[string][]int
+}
+
+// routeSheets spreads elements of the slice across sheets, elements without a sheet name go to sheetName
+func routeSheets(sheetName string, slice reflect.Value, router func(v interface{}) string) *routes {
+	r := &routes{sheets: map[string]reflect.Value{}, indexes: map[string][]int{}}
+	for i := 0; i < slice.Len(); i++ {
+		element := slice.Index(i)
+		name := router(element.Interface())
+		if name == "" {
+			name = sheetName
+		}
+
+		rows, ok := r.sheets[name]
+		if !ok {
+			r.sheetNames = append(r.sheetNames, name)
+			rows = reflect.MakeSlice(slice.Type(), 0, 0)
+		}
+		r.sheets[name] = reflect.Append(rows, element)
+		r.indexes[name] = append(r.indexes[name], i)
+	}
+	return r
+}
+
+// writeSheet recreates the sheet and writes the slice to it
+func writeSheet(file *excelize.File, sheetName string, slice reflect.Value, columns []column, o *options) error {
+	// Sheets of WithSheetOrder are already created empty in their order
+	if !o.created[sheetName] {
+		file.DeleteSheet(sheetName)
+	}
+	if o.write.IndexSheet != "" {
+		// Create the index before data sheets so it stays the first tab
+		ensureSheet(file, o.write.IndexSheet)
+	}
 	file.NewSheet(sheetName)
 	file.DeleteSheet("Sheet1")
 
-	style, _ := file.NewStyle(&excelize.Style{Font: &excelize.Font{
-		Family: "Helvetica Neue",
-		Size:   10,
-		Color:  "#000000",
-	}})
+	styles, err := newSheetStyles(file, columns, o.write)
+	if err != nil {
+		return err
+	}
+
+	locked := lockedColumns(columns)
+	if len(locked) > 0 {
+		if err := unlockInputColumns(file, sheetName, columns, styles); err != nil {
+			return err
+		}
+	}
+
+	headerRow, typeRow, totalsRow, dataRow := o.write.sheetRows(columns)
+	dataRows := slice.Len()
+	if o.write.Banner != "" {
+		if err := writeBanner(file, sheetName, columns, o.write.Banner); err != nil {
+			return err
+		}
+	}
 
-	slice := reflect.ValueOf(data)
 	if slice.Len() > 0 {
-		// Set column names
-		e := slice.Index(0)
-		for i := 0; i < e.NumField(); i++ {
-			var field = e.Type().Field(i)
+		if err := writeHeader(file, sheetName, columns, headerRow, styles); err != nil {
+			return err
+		}
+
+		// Set rows
+		if o.write.Grouping.GroupBy != "" {
+			written, err := writeGroups(file, sheetName, slice, columns, dataRow, styles, o)
+			if err != nil {
+				return err
+			}
+			dataRows = written
+		} else {
+			for rowi := 0; rowi < slice.Len(); rowi++ {
+				err := writeRow(file, sheetName, slice.Index(rowi), rowi, dataRow+rowi, columns, styles, o)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := mergeVerticalColumns(file, sheetName, columns, dataRow, dataRow+dataRows-1); err != nil {
+			return err
+		}
+		if err := setConditionalFormats(file, sheetName, columns, dataRow, dataRow+dataRows-1, o.write); err != nil {
+			return err
+		}
 
-			// Skip column if tag is "-"
-			if field.Tag.Get("xlsx") == "-" {
-				continue
+		if typeRow > 0 {
+			if err := writeTypeRow(file, sheetName, columns, typeRow); err != nil {
+				return err
 			}
+		}
 
-			err := file.SetCellValue(sheetName, GetCellName(i, 1), getColumnName(field))
+		if totalsRow > 0 {
+			err := writeTotals(file, sheetName, columns, totalsRow, dataRow, dataRow+dataRows-1, o.write)
 			if err != nil {
 				return err
 			}
-			file.SetCellStyle(sheetName, GetCellName(i, 1), GetCellName(i, 1), style)
+			if err := markRow(file, sheetName, columns, totalsRow, totalsRowKind); err != nil {
+				return err
+			}
+		}
 
-			columnWidth := getColumnWidth(field)
-			if columnWidth != nil {
-				file.SetColWidth(sheetName, getColumnLetter(i), getColumnLetter(i), *columnWidth)
+		if o.write.FreezeHeader {
+			if err := file.SetPanes(sheetName, freezePanes(dataRow)); err != nil {
+				return err
 			}
 		}
 
-		file.SetRowHeight(sheetName, 1, 18)
+		if o.write.AutoFilter {
+			if err := setAutoFilter(file, sheetName, columns, dataRow-1, dataRow+dataRows-1); err != nil {
+				return err
+			}
+		}
+	}
 
-		// Set rows
-		for rowi := 0; rowi < slice.Len(); rowi++ {
+	if o.dataRows != nil {
+		o.dataRows[sheetName] = dataRows
+	}
 
-			file.SetRowHeight(sheetName, rowi+2, 18)
+	if len(o.write.FooterRows) > 0 {
+		footerRow := dataRow + dataRows + 1
+		if err := writeFooter(file, sheetName, columns, footerRow, o.write.FooterRows); err != nil {
+			return err
+		}
+		for i := range o.write.FooterRows {
+			if err := markRow(file, sheetName, columns, footerRow+i, footerRowKind); err != nil {
+				return err
+			}
+		}
+	}
 
-			element := slice.Index(rowi)
-			for columni := 0; columni < element.NumField(); columni++ {
+	if len(locked) > 0 {
+		if err := protectSheet(file, sheetName, columns, headerRow, o.write.SheetPassword); err != nil {
+			return err
+		}
+	}
 
-				// Skip column if tag is "-"
-				if element.Type().Field(columni).Tag.Get("xlsx") == "-" {
-					continue
-				}
+	if o.write.Schema {
+		if err := writeSchema(file, sheetName, columns); err != nil {
+			return err
+		}
+	}
 
-				value := element.Field(columni)
-				if value.Kind() == reflect.Ptr {
-					value = value.Elem()
-				}
+	if o.write.Provenance != nil {
+		if err := writeProvenanceHeader(file, sheetName, columns, headerRow); err != nil {
+			return err
+		}
+	}
 
-				var cellValue interface{} = ""
-				if value.IsValid() {
-					cellValue = value.Interface()
-
-					if t, ok := value.Interface().(time.Time); ok {
-						cellValue = t.Format("2006-01-02 15:04:05")
-					} else if isNumeric(value) {
-						cellValue = getNumeric(e.Type().Field(columni), value)
-					}
-
-					if getTagBool(e.Type().Field(columni), "emptyIfZero") {
-						if fmt.Sprint(cellValue) == "0" {
-							cellValue = ""
-						} else if t, ok := value.Interface().(time.Time); ok && t.IsZero() {
-							cellValue = ""
-						}
-					}
-				}
+	if o.write.marksRows(columns) {
+		if err := writeRowKindHeader(file, sheetName, columns, headerRow); err != nil {
+			return err
+		}
+	}
 
-				err := file.SetCellValue(sheetName, GetCellName(columni, rowi+2), cellValue)
-				if err != nil {
-					return err
-				}
-				file.SetCellStyle(sheetName, GetCellName(columni, rowi+2), GetCellName(columni, rowi+2), style)
+	if o.write.IndexSheet != "" && o.write.IndexBackLink != "" {
+		// The link goes after the last column with a gap, hidden columns are skipped
+		columnIdx := lastColumnIndex(columns) + 2
+		if o.write.Provenance != nil {
+			columnIdx = provenanceColumnIndex(columns) + len(provenanceHeaders)
+		}
+		if o.write.marksRows(columns) {
+			columnIdx = rowKindColumnIndex(columns) + 1
+		}
+		err := writeBackLink(file, sheetName, columnIdx, headerRow, o.write.IndexSheet, o.write.IndexBackLink)
+		if err != nil {
+			return err
+		}
+	}
+
+	if o.write.OnLayout != nil {
+		layout := newLayout(sheetName, columns, headerRow, totalsRow, dataRow, dataRows)
+		if len(o.write.FooterRows) > 0 {
+			footerRow := dataRow + dataRows + 1
+			layout.Footer = CellRange{
+				FromColumn: 0,
+				FromRow:    footerRow,
+				ToColumn:   layout.Data.ToColumn,
+				ToRow:      footerRow + len(o.write.FooterRows) - 1,
 			}
 		}
+		o.write.OnLayout(layout)
+	}
+
+	if o.write.Precalc {
+		lastRow := dataRow + dataRows - 1
+		if len(o.write.FooterRows) > 0 {
+			lastRow = dataRow + dataRows + len(o.write.FooterRows)
+		}
+		return precalcSheet(file, sheetName, lastColumnIndex(columns), lastRow)
 	}
 	return nil
 }
 
+// writeHeader writes column names with descriptions and sets column widths
+func writeHeader(file *excelize.File, sheetName string, columns []column, headerRow int, styles *sheetStyles) error {
+	for _, c := range columns {
+		cell := GetCellName(c.index, headerRow)
+		err := file.SetCellValue(sheetName, cell, c.name)
+		if err != nil {
+			return err
+		}
+		file.SetCellStyle(sheetName, cell, cell, styles.header)
+
+		if desc := getTag(c.field, "desc"); desc != "" {
+			if err := file.AddComment(sheetName, excelize.Comment{Cell: cell, Text: desc}); err != nil {
+				return err
+			}
+		}
+
+		columnWidth := getColumnWidth(c.field)
+		if columnWidth != nil {
+			file.SetColWidth(sheetName, getColumnLetter(c.index), getColumnLetter(c.index), *columnWidth)
+		}
+	}
+
+	file.SetRowHeight(sheetName, headerRow, 18)
+	return nil
+}
+
+// writeRow writes the slice element to the sheet row
+func writeRow(file *excelize.File, sheetName string, element reflect.Value, rowi int, rowIdx int, columns []column, styles *sheetStyles, o *options) error {
+	file.SetRowHeight(sheetName, rowIdx, 18)
+
+	rowCtx := WriteContext{
+		File:      file,
+		SheetName: sheetName,
+		Index:     rowi,
+		Element:   element.Interface(),
+		RowIdx:    rowIdx,
+	}
+
+	for _, c := range columns {
+		cellValue, err := c.cellValue(element)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", c.field.Name, err)
+		}
+
+		cellValue, link, err := c.hyperlink(element, cellValue)
+		if err != nil {
+			return err
+		}
+		if cellValue, err = o.write.transformValue(c.field, cellValue); err != nil {
+			return err
+		}
+
+		cell := GetCellName(c.index, rowIdx)
+		if o.write.OnField != nil {
+			ctx := rowCtx
+			ctx.ColumnIdx = c.index
+			ctx.Cell = cell
+			ctx.Field = c.field
+			ctx.Value = cellValue
+			if err := o.write.OnField(&ctx); err != nil {
+				return err
+			}
+			cellValue = ctx.Value
+		}
+
+		formula, err := c.formula(columns, rowIdx)
+		if err != nil {
+			return err
+		}
+		if formula != "" {
+			err = file.SetCellFormula(sheetName, cell, formula)
+		} else {
+			err = setCellValue(file, sheetName, cell, cellValue)
+		}
+		if err != nil {
+			return err
+		}
+		if err := setHyperlink(file, sheetName, cell, link); err != nil {
+			return err
+		}
+		file.SetCellStyle(sheetName, cell, cell, styles.column(c.index))
+	}
+
+	if o.write.OnRow != nil {
+		if err := o.write.OnRow(&rowCtx); err != nil {
+			return err
+		}
+	}
+
+	if o.write.Provenance != nil {
+		sourceIndex := rowi
+		if o.sourceIndexes != nil {
+			sourceIndex = o.sourceIndexes[rowi]
+		}
+		if err := writeProvenance(file, sheetName, columns, sourceIndex, rowIdx, o.write.Provenance); err != nil {
+			return err
+		}
+	}
+	o.progress.add()
+
+	if locked := lockedColumns(columns); len(locked) > 0 {
+		return writeRowProtection(file, sheetName, columns, locked, rowIdx, styles)
+	}
+	return nil
+}
+
+// setCellValue sets the cell value like excelize, which overflows unsigned integers above math.MaxInt64
+func setCellValue(file *excelize.File, sheetName string, cell string, value interface{}) error {
+	switch v := value.(type) {
+	case uint64:
+		return file.SetCellDefault(sheetName, cell, strconv.FormatUint(v, 10))
+	case uint:
+		return file.SetCellDefault(sheetName, cell, strconv.FormatUint(uint64(v), 10))
+	case json.Number:
+		return file.SetCellDefault(sheetName, cell, v.String())
+	}
+	return file.SetCellValue(sheetName, cell, value)
+}
+
+// writeBanner writes the banner text in the first row merged across all columns
+func writeBanner(file *excelize.File, sheetName string, columns []column, text string) error {
+	lastColumn := lastColumnIndex(columns)
+
+	if err := file.SetCellValue(sheetName, "A1", text); err != nil {
+		return err
+	}
+	if lastColumn > 0 {
+		if err := file.MergeCell(sheetName, "A1", GetCellName(lastColumn, 1)); err != nil {
+			return err
+		}
+	}
+
+	font := defaultFont
+	font.Bold = true
+	font.Color = "#C00000"
+	style, _ := file.NewStyle(&excelize.Style{
+		Font:      &font,
+		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"#F2F2F2"}},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center"},
+	})
+	file.SetCellStyle(sheetName, "A1", GetCellName(lastColumn, 1), style)
+	return file.SetRowHeight(sheetName, 1, 22)
+}
+
+// writeFooter writes every line in its own row merged across all columns
+func writeFooter(file *excelize.File, sheetName string, columns []column, footerRow int, lines []string) error {
+	lastColumn := lastColumnIndex(columns)
+
+	font := defaultFont
+	font.Italic = true
+	font.Color = "#595959"
+	style, _ := file.NewStyle(&excelize.Style{
+		Font:      &font,
+		Alignment: &excelize.Alignment{WrapText: true, Vertical: "top"},
+	})
+
+	for i, line := range lines {
+		rowIdx := footerRow + i
+		if err := file.SetCellValue(sheetName, GetCellName(0, rowIdx), line); err != nil {
+			return err
+		}
+		if lastColumn > 0 {
+			if err := file.MergeCell(sheetName, GetCellName(0, rowIdx), GetCellName(lastColumn, rowIdx)); err != nil {
+				return err
+			}
+		}
+		file.SetCellStyle(sheetName, GetCellName(0, rowIdx), GetCellName(lastColumn, rowIdx), style)
+	}
+	return nil
+}
+
+// getCellValue converts the field value to the value written to the cell
+func getCellValue(field reflect.StructField, value reflect.Value) (interface{}, error) {
+	if getTagBool(field, "omitempty") && isZero(value) {
+		return "", nil
+	}
+	if m, ok := asMarshaler(value); ok {
+		return m.MarshalXLSXCell()
+	}
+	if m, ok := asTextMarshaler(value); ok && getTagBool(field, "decimal") {
+		// Decimals like shopspring Decimal keep all their digits, text that isn't a number stays text
+		text, err := m.MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		if n, ok := decimalNumber(string(text)); ok {
+			return n, nil
+		}
+		return string(text), nil
+	}
+	if v, ok := asValuer(value); ok {
+		// Database values like sql.NullString are written as the values they store
+		dv, err := v.Value()
+		if err != nil || dv == nil {
+			return "", err
+		}
+		if b, ok := dv.([]byte); ok {
+			dv = string(b)
+		}
+		return getCellValue(field, reflect.ValueOf(dv))
+	}
+
+	if value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+	if isSeparatedField(field, value) {
+		return joinSeparated(field, value)
+	}
+
+	var cellValue interface{} = ""
+	if value.IsValid() {
+		cellValue = value.Interface()
+
+		if n, ok := bigNumber(value); ok {
+			cellValue = n
+		} else if t, ok := value.Interface().(time.Time); ok {
+			// Times with the datefmt tag are serial numbers formatted by the column style
+			if getTag(field, "datefmt") == "" {
+				cellValue = t.Format("2006-01-02 15:04:05")
+			}
+		} else if d, ok := value.Interface().(time.Duration); ok {
+			// Durations are serial numbers formatted as elapsed time by the column style
+			cellValue = durationSerial(d)
+		} else if m, ok := asTextMarshaler(value); ok {
+			text, err := m.MarshalText()
+			if err != nil {
+				return nil, err
+			}
+			cellValue = string(text)
+		} else if isNumeric(value) {
+			cellValue = getNumeric(field, value)
+		}
+
+		if getTagBool(field, "emptyIfZero") {
+			if fmt.Sprint(cellValue) == "0" {
+				cellValue = ""
+			} else if t, ok := value.Interface().(time.Time); ok && t.IsZero() {
+				cellValue = ""
+			}
+		}
+	}
+	return cellValue, nil
+}
+
+// isZero reports whether the value is nil, the zero value of its type or zero by its IsZero method
+func isZero(value reflect.Value) bool {
+	if !value.IsValid() {
+		return true
+	}
+	if value.Kind() == reflect.Ptr && value.IsNil() {
+		return true
+	}
+	if z, ok := value.Interface().(interface{ IsZero() bool }); ok {
+		return z.IsZero()
+	}
+	if value.CanAddr() {
+		if z, ok := value.Addr().Interface().(interface{ IsZero() bool }); ok {
+			return z.IsZero()
+		}
+	}
+	return value.IsZero()
+}
+
+// asMarshaler returns the value as Marshaler if the value or its pointer implements it
+func asMarshaler(value reflect.Value) (Marshaler, bool) {
+	if value.Kind() == reflect.Ptr && value.IsNil() {
+		return nil, false
+	}
+	if m, ok := value.Interface().(Marshaler); ok {
+		return m, true
+	}
+	if value.CanAddr() {
+		if m, ok := value.Addr().Interface().(Marshaler); ok {
+			return m, true
+		}
+	}
+	return nil, false
+}
+
+// asTextMarshaler returns the value as encoding.TextMarshaler if the value or its pointer implements it
+func asTextMarshaler(value reflect.Value) (encoding.TextMarshaler, bool) {
+	m, ok := asImplementer(value, textMarshalerType)
+	if !ok {
+		return nil, false
+	}
+	return m.(encoding.TextMarshaler), true
+}
+
+// asValuer returns the value as driver.Valuer if the value or its pointer implements it
+func asValuer(value reflect.Value) (driver.Valuer, bool) {
+	v, ok := asImplementer(value, valuerType)
+	if !ok {
+		return nil, false
+	}
+	return v.(driver.Valuer), true
+}
+
+// asImplementer returns the value or its pointer implementing the interface type, nil pointers don't implement it
+func asImplementer(value reflect.Value, iface reflect.Type) (interface{}, bool) {
+	switch {
+	case !value.IsValid(), value.Kind() == reflect.Ptr && value.IsNil():
+		return nil, false
+	case value.Type().Implements(iface):
+		return value.Interface(), true
+	case value.CanAddr() && value.Addr().Type().Implements(iface):
+		return value.Addr().Interface(), true
+	}
+	return nil, false
+}
+
 // WriteMatrix adds data to the sheet
-// start - start cell name
+// start - start cell name like "AA5" or "$b$2", A1 if empty
 func WriteMatrix(file *excelize.File, sheetName string, start string, data [][]interface{}) error {
-	var (
-		startColumnIdx int
-		startRowIdx    int
-	)
-
-	if len(start) > 0 {
-		startColumnIdx = int(start[0] - 'A')
-		startRowIdx, _ = strconv.Atoi(start[1:])
+	startColumnIdx, startRowIdx := 0, 1
+	if start != "" {
+		var err error
+		startColumnIdx, startRowIdx, err = ParseCellName(strings.ReplaceAll(start, "$", ""))
+		if err != nil {
+			return fmt.Errorf("invalid start cell %q: %w", start, err)
+		}
 	}
 
 	for rowi := 0; rowi < len(data); rowi++ {
 		for columni := 0; columni < len(data[rowi]); columni++ {
-			err := file.SetCellValue(sheetName, GetCellName(startColumnIdx+columni, startRowIdx+rowi), data[rowi][columni])
+			cell, err := IndicesToCellName(startColumnIdx+columni, startRowIdx+rowi)
 			if err != nil {
 				return err
 			}
+			if err := file.SetCellValue(sheetName, cell, data[rowi][columni]); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
@@ -148,7 +737,8 @@ func WriteMatrix(file *excelize.File, sheetName string, start string, data [][]i
 func getTag(field reflect.StructField, tag string) string {
 	tags := field.Tag.Get("xlsx")
 	for _, tagValue := range strings.Split(tags, ";") {
-		tagSplit := strings.Split(tagValue, ":")
+		// Values can have colons like desc texts and time formats
+		tagSplit := strings.SplitN(tagValue, ":", 2)
 		if len(tagSplit) == 2 && tagSplit[0] == tag {
 			return tagSplit[1]
 		}
@@ -166,12 +756,205 @@ func getTagBool(field reflect.StructField, tag string) bool {
 	return false
 }
 
+// column is a struct field written to the sheet
+// Fields of nested structs have the full index and the dotted name, e.g. "Audit.CreatedAt"
+type column struct {
+	field reflect.StructField
+	index int
+	name  string
+	// aliases are header names accepted on read, the name is the first one
+	aliases []string
+	// restKey is the map key the column holds, the map is the rest field (see restField)
+	// or the element itself if the field has no index (see getMapColumns)
+	restKey string
+	// units are suffixes of the unit tag trimmed on read, the first one is written
+	units []string
+}
+
+// cellValue returns the value of the column cell of the element
+func (c column) cellValue(element reflect.Value) (interface{}, error) {
+	value := element
+	if len(c.field.Index) > 0 {
+		value = element.FieldByIndex(c.field.Index)
+	}
+	if c.restKey != "" {
+		value = value.MapIndex(reflect.ValueOf(c.restKey))
+		if value.Kind() == reflect.Interface {
+			value = value.Elem()
+		}
+		if !value.IsValid() {
+			return "", nil
+		}
+	}
+	return getCellValue(c.field, value)
+}
+
+// getColumns returns fields of the struct type which are written to the sheet.
+// Columns follow each other in order of fields, skipped fields with the "-" tag take no column.
+// The col and index tags put the field to the given column, next fields follow it
+// Fields of embedded and nested structs are columns too, see isNestedStruct
+// Tags scoped to template versions are ignored, see WithTemplateVersion
+// Fields with the readonly tag are only read and take no column, see readColumns
+// Columns are cached by type, callers must not change the returned slice
+func getColumns(t reflect.Type) []column {
+	return cachedColumns(t, "readonly", "")
+}
+
+// readColumns returns fields of the struct type which are read from the sheet of the template version like getColumns,
+// fields with the writeonly tag like computed values are only written and take no column, see WithTemplateVersion
+func readColumns(t reflect.Type, version string) []column {
+	return cachedColumns(t, "writeonly", version)
+}
+
+// cachedColumns returns columns of the struct type with tags of the version without fields with the skip tag
+func cachedColumns(t reflect.Type, skip string, version string) []column {
+	key := columnsKey{t: t, skip: skip, version: version}
+	if cached, ok := columnsCache.Load(key); ok {
+		return cached.([]column)
+	}
+	next := 0
+	columns := appendColumns(nil, t, nil, "", "", skip, version, &next)
+	columnsCache.Store(key, columns)
+	return columns
+}
+
+// columnsKey is the key of columnsCache
+type columnsKey struct {
+	t       reflect.Type
+	skip    string
+	version string
+}
+
+// columnsCache maps struct types to their columns
+var columnsCache sync.Map
+
+// appendColumns appends fields of the struct type starting from the column next
+// Fields of embedded structs are promoted, header names of nested struct fields get
+// the parent name and a dot as a prefix, the prefix tag replaces the prefix
+func appendColumns(columns []column, t reflect.Type, parentIndex []int, fieldPrefix string, namePrefix string, skip string, version string, next *int) []column {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		field.Index = append(append([]int{}, parentIndex...), i)
+		field.Tag = versionTag(field.Tag, version)
+		if field.Tag.Get("xlsx") == "-" {
+			continue
+		}
+		if getTagBool(field, "children") || getTagBool(field, "rest") || getTagBool(field, skip) {
+			continue
+		}
+
+		if isNestedStruct(field.Type) {
+			childFieldPrefix, childNamePrefix := fieldPrefix, namePrefix
+			if !field.Anonymous {
+				childFieldPrefix += field.Name + "."
+				childNamePrefix += getColumnName(field) + "."
+			}
+			if prefix := getTag(field, "prefix"); prefix != "" {
+				childNamePrefix = namePrefix + prefix
+			}
+			columns = appendColumns(columns, field.Type, field.Index, childFieldPrefix, childNamePrefix, skip, version, next)
+			continue
+		}
+
+		if columnIdx, ok := getColumnIndex(field); ok {
+			*next = columnIdx
+		}
+
+		var aliases []string
+		for _, alias := range getColumnNames(field) {
+			aliases = append(aliases, namePrefix+alias)
+		}
+		field.Name = fieldPrefix + field.Name
+		columns = append(columns, column{field: field, index: *next, name: aliases[0], aliases: aliases, units: getUnits(field)})
+		*next++
+	}
+	return columns
+}
+
+// checkColumns returns DuplicateColumnError if fields share the header or the column,
+// the later one would overwrite the former and Unmarshal couldn't tell them apart
+// Keys of rest maps are data, so their columns aren't checked
+func checkColumns(columns []column) error {
+	names, indexes := map[string]column{}, map[int]column{}
+	for _, c := range columns {
+		if c.restKey != "" {
+			continue
+		}
+		if prev, ok := indexes[c.index]; ok {
+			return &DuplicateColumnError{Column: getColumnLetter(c.index), Fields: []string{prev.field.Name, c.field.Name}}
+		}
+		if prev, ok := names[c.name]; ok {
+			return &DuplicateColumnError{Column: strconv.Quote(c.name), Fields: []string{prev.field.Name, c.field.Name}}
+		}
+		indexes[c.index], names[c.name] = c, c
+	}
+	return nil
+}
+
+// isNestedStruct reports whether fields of the struct type are written as separate columns
+// Types converting themselves to cell values are written to one column
+func isNestedStruct(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || t == reflect.TypeOf(time.Time{}) {
+		return false
+	}
+	ptr := reflect.PtrTo(t)
+	for _, iface := range []reflect.Type{marshalerType, unmarshalerType, textMarshalerType, textUnmarshalerType, valuerType, scannerType} {
+		if ptr.Implements(iface) {
+			return false
+		}
+	}
+	return true
+}
+
+var (
+	marshalerType       = reflect.TypeOf((*Marshaler)(nil)).Elem()
+	unmarshalerType     = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	valuerType          = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	scannerType         = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+)
+
+// lastColumnIndex returns index of the rightmost column, 0 if there are no columns
+func lastColumnIndex(columns []column) int {
+	last := 0
+	for _, c := range columns {
+		if c.index > last {
+			last = c.index
+		}
+	}
+	return last
+}
+
+// getColumnIndex returns the zero based column index set by the col tag (letter) or the index tag (one based number)
+func getColumnIndex(field reflect.StructField) (int, bool) {
+	if letter := getTag(field, "col"); letter != "" {
+		number, err := excelize.ColumnNameToNumber(letter)
+		if err == nil {
+			return number - 1, true
+		}
+	}
+	if index := getTag(field, "index"); index != "" {
+		number, err := strconv.Atoi(index)
+		if err == nil && number >= 1 && number <= excelize.MaxColumns {
+			return number - 1, true
+		}
+	}
+	return 0, false
+}
+
 func getColumnName(field reflect.StructField) string {
+	return getColumnNames(field)[0]
+}
+
+// getColumnNames returns header names of the name tag separated by "|", e.g. name:Телефон|Phone|Tel
+// The first name is written, all of them are accepted on read
+func getColumnNames(field reflect.StructField) []string {
 	columnName := getTag(field, "name")
 	if len(columnName) > 0 {
-		return columnName
+		return strings.Split(columnName, "|")
 	}
-	return field.Name
+	return []string{field.Name}
 }
 
 func getColumnWidth(field reflect.StructField) *float64 {
@@ -192,22 +975,27 @@ func isNumeric(value reflect.Value) bool {
 	return false
 }
 
-func getNumeric(field reflect.StructField, v reflect.Value) float64 {
+// getNumeric applies divide and round tags, integers without them are kept exact
+func getNumeric(field reflect.StructField, v reflect.Value) interface{} {
+	divide := getTag(field, "divide")
+	round := getTag(field, "round")
+	if v.Kind() == reflect.Int64 && divide == "" && round == "" {
+		return v.Int()
+	}
+
 	var f float64
-	if tmp, ok := v.Interface().(float64); ok {
-		f = tmp
-	} else if tmp, ok := v.Interface().(int64); ok {
-		f = float64(tmp)
+	if v.Kind() == reflect.Float64 {
+		f = v.Float()
+	} else {
+		f = float64(v.Int())
 	}
 
-	divide := getTag(field, "divide")
 	if len(divide) > 0 {
 		if i, err := strconv.Atoi(divide); err == nil {
 			f = f / float64(i)
 		}
 	}
 
-	round := getTag(field, "round")
 	if len(round) > 0 {
 		if i, err := strconv.Atoi(round); err == nil {
 			f = math.Round(f*float64(i)) / float64(i)
@@ -216,14 +1004,12 @@ func getNumeric(field reflect.StructField, v reflect.Value) float64 {
 	return f
 }
 
+// GetCellName returns the cell name of the zero based column index and the row index, e.g. "AAA1" for 702 and 1
 func GetCellName(columnIdx int, rowIdx int) string {
 	return fmt.Sprintf("%s%d", getColumnLetter(columnIdx), rowIdx)
 }
 
 func getColumnLetter(columnIdx int) string {
-	if columnIdx < 26 {
-		return string('A' + columnIdx)
-	} else {
-		return string('A'-1+columnIdx/26) + string('A'+columnIdx%26)
-	}
+	name, _ := excelize.ColumnNumberToName(columnIdx + 1)
+	return name
 }